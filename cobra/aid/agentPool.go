@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetAgentPoolFlags define flags for the cobra command
+func SetAgentPoolFlags(cmd *cobra.Command) {
+	usage := `The agent pool ID. Required for read, update and delete.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The name of the agent pool. Required for create and update.`
+	cmd.Flags().String("name", "", usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// SetAgentPoolLookupFlags define the flag used to find an agent pool by name instead of ID
+func SetAgentPoolLookupFlags(cmd *cobra.Command) {
+	usage := `The name of the agent pool, resolved to its ID within --organization. Can be used instead of --agent-pool-id.`
+	cmd.Flags().String("agent-pool", "", usage)
+}
+
+// GetAgentPoolCreateOptions return options based on the flags values
+func GetAgentPoolCreateOptions(cmd *cobra.Command) tfe.AgentPoolCreateOptions {
+	var options tfe.AgentPoolCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag name\n%v", err)
+	}
+
+	options.Name = &name
+
+	return options
+}
+
+// GetAgentPoolUpdateOptions return options based on the flags values
+func GetAgentPoolUpdateOptions(cmd *cobra.Command) tfe.AgentPoolUpdateOptions {
+	var options tfe.AgentPoolUpdateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag name\n%v", err)
+	}
+
+	options.Name = &name
+
+	return options
+}
+
+// PrintAgentPoolList renders the list items using the given output format
+func PrintAgentPoolList(list *tfe.AgentPoolList, format string) {
+	PrintOutput(format, list.Items)
+}
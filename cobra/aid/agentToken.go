@@ -0,0 +1,49 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// SetAgentTokenFlags define flags for the cobra command
+func SetAgentTokenFlags(cmd *cobra.Command) {
+	usage := `The agent token ID. Required for read and delete.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The agent pool ID. Required for list and generate.`
+	cmd.Flags().String("agent-pool-id", "", usage)
+	SetAgentPoolLookupFlags(cmd)
+
+	usage = `A description of the agent token, to tell the agents apart.`
+	cmd.Flags().String("description", "", usage)
+
+	usage = `Write the generated token to this file, created with 0600 permissions, instead of printing it.`
+	cmd.Flags().String("token-file", "", usage)
+}
+
+// GetAgentTokenGenerateOptions return options based on the flags values
+func GetAgentTokenGenerateOptions(cmd *cobra.Command) tfe.AgentTokenGenerateOptions {
+	var options tfe.AgentTokenGenerateOptions
+	options.Description = getStringFlagIfChanged(cmd, "description")
+	return options
+}
+
+// PrintAgentTokenList renders the list items using the given output format
+func PrintAgentTokenList(list *tfe.AgentTokenList, format string) {
+	PrintOutput(format, list.Items)
+}
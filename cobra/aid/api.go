@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// apiClient calls the Terraform Cloud API endpoints the go-tfe client doesn't cover yet
+type apiClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func newAPIClient(token string, address string) apiClient {
+	config := getTFEConfig(token, address)
+	return apiClient{
+		baseURL: strings.TrimSuffix(config.Address, "/") + "/" + strings.Trim(config.BasePath, "/") + "/",
+		token:   token,
+		httpClient: &http.Client{
+			// downloads are redirected to pre-signed archive links, which must not receive the token
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 10 {
+					return fmt.Errorf("stopped after 10 redirects")
+				}
+				req.Header.Del("Authorization")
+				return nil
+			},
+		},
+	}
+}
+
+// do sends a JSON:API request and decodes the response into v, unless v is nil
+func (a *apiClient) do(ctx context.Context, method string, path string, body interface{}, v interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := a.newRequest(method, path, r)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/vnd.api+json")
+	}
+
+	b, err := a.send(req.WithContext(ctx))
+	if err != nil || v == nil || len(b) == 0 {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// get returns the raw content of the endpoint, following redirects to the archive it may be stored in
+func (a *apiClient) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := a.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.send(req.WithContext(ctx))
+}
+
+func (a *apiClient) newRequest(method string, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, a.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	req.Header.Set("Accept", "application/vnd.api+json")
+
+	return req, nil
+}
+
+// send returns the body of the response, or an error holding it when the status isn't successful
+func (a *apiClient) send(req *http.Request) ([]byte, error) {
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s returned %s\n%s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return b, nil
+}
@@ -25,4 +25,7 @@ import (
 func SetApplyFlags(cmd *cobra.Command) {
 	usage := `The Apply ID`
 	cmd.Flags().String("id", "", usage)
+
+	// Logs
+	SetLogsFlags(cmd)
 }
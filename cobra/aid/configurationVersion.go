@@ -16,8 +16,6 @@ limitations under the License.
 package aid
 
 import (
-	"fmt"
-
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -41,6 +39,7 @@ func SetConfigurationVersionFlags(cmd *cobra.Command) {
 
 	usage = `The Workspace ID`
 	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
 
 	// Upload packages and uploads Terraform configuration files. It requires
 	// the upload URL from a configuration version and the full path to the
@@ -50,6 +49,9 @@ func SetConfigurationVersionFlags(cmd *cobra.Command) {
 
 	usage = `The upload path`
 	cmd.Flags().String("path", "", usage)
+
+	// List
+	SetPaginationFlags(cmd)
 }
 
 // GetConfigurationVersionCreateOptions return options based on the flags values
@@ -73,15 +75,7 @@ func GetConfigurationVersionCreateOptions(cmd *cobra.Command) tfe.ConfigurationV
 	return options
 }
 
-// PrintConfigurationVersionList TODO ...
-func PrintConfigurationVersionList(list *tfe.ConfigurationVersionList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
-	}
+// PrintConfigurationVersionList renders the list items using the given output format
+func PrintConfigurationVersionList(list *tfe.ConfigurationVersionList, format string) {
+	PrintOutput(format, list.Items)
 }
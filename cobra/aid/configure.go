@@ -19,7 +19,6 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
 	"path"
@@ -57,6 +56,15 @@ func SetConfigureFlags(cmd *cobra.Command) {
 
 	usage = `API tokens may generated for a specific organization. Organization API tokens allow access to the organization-level settings and resources, without being tied to any specific team or user.`
 	cmd.Flags().String("organization-token", "", usage)
+
+	usage = `The address of your Terraform Enterprise instance, for example https://tfe.example.com. Defaults to Terraform Cloud (https://app.terraform.io).`
+	cmd.Flags().String("address", "", usage)
+
+	usage = `Encrypt or decrypt every profile of the credentials file, instead of the given profile only.`
+	cmd.Flags().Bool("all", false, usage)
+
+	usage = `Where to import the profile tokens from. Valid values: terraform, which reads the Terraform CLI credentials for the hostname of --address.`
+	cmd.Flags().String("from", "", usage)
 }
 
 // GetCredentialProfileFlags TODO ...
@@ -124,6 +132,15 @@ func GetCredentialProfileFlags(cmd *cobra.Command) model.CredentialProfile {
 		cp.OrganizationToken = organizationToken
 	}
 
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		logrus.Fatalf("unable to get flag address\n%v", err)
+	}
+
+	if address != "" {
+		cp.Address = address
+	}
+
 	return cp
 }
 
@@ -157,6 +174,10 @@ func UpdateCredentialProfile(cmd *cobra.Command, old model.CredentialProfile) mo
 		old.OrganizationToken = f.OrganizationToken
 	}
 
+	if f.Address != "" && f.Address != old.Address {
+		old.Address = f.Address
+	}
+
 	return old
 }
 
@@ -261,7 +282,8 @@ func WriteInterfaceToFile(in interface{}, path string) error {
 		}
 	}
 
-	err = ioutil.WriteFile(path, b, os.ModePerm)
+	// files might contain tokens, only the owner can read them, even while they're written
+	err = WriteSecretFile(path, b)
 	if err != nil {
 		return fmt.Errorf("unable to update:%s\n%v", path, err)
 	}
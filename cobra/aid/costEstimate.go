@@ -0,0 +1,196 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// Formats of the cost estimate report
+const (
+	CostEstimateReportTable = "table"
+	CostEstimateReportCSV   = "csv"
+)
+
+// CostEstimateReportErrored is the status of the rows whose run or cost estimate couldn't be read
+const CostEstimateReportErrored tfe.CostEstimateStatus = "error"
+
+// CostEstimateReport gathers the cost estimate of the current run of every workspace of an organization
+type CostEstimateReport struct {
+	Organization string
+	Workspaces   []CostEstimateReportRow
+	// Total sums the monthly costs of the finished cost estimates only
+	Total CostEstimateReportTotal
+}
+
+// CostEstimateReportRow is the cost estimate of the current run of a workspace
+type CostEstimateReportRow struct {
+	Workspace           string
+	WorkspaceID         string
+	RunID               string
+	CostEstimateID      string
+	Status              tfe.CostEstimateStatus
+	PriorMonthlyCost    float64
+	ProposedMonthlyCost float64
+	DeltaMonthlyCost    float64
+}
+
+// CostEstimateReportTotal is the monthly cost of the organization
+type CostEstimateReportTotal struct {
+	Workspaces          int
+	PriorMonthlyCost    float64
+	ProposedMonthlyCost float64
+	DeltaMonthlyCost    float64
+}
+
+// SetCostEstimateFlags define flags for the cobra command
+func SetCostEstimateFlags(cmd *cobra.Command) {
+	usage := `The cost estimate ID. Required for read and logs.`
+	cmd.Flags().String("id", "", usage)
+
+	// Report
+	usage = `The format of the report. Valid values: table or csv. Ignored when --output is given, the report is then rendered using the output format.`
+	cmd.Flags().String("format", CostEstimateReportTable, usage)
+
+	// Logs
+	SetLogsFlags(cmd)
+}
+
+// ValidateCostEstimateReportFormat returns an error if the given report format isn't supported
+func ValidateCostEstimateReportFormat(format string) error {
+	switch format {
+	case CostEstimateReportTable, CostEstimateReportCSV:
+		return nil
+	}
+
+	return fmt.Errorf("invalid value for --format: %s\nvalid values: table or csv", format)
+}
+
+// NewCostEstimateReportRow returns the report row of the cost estimate of a workspace's run.
+// The monthly costs are decimal strings, empty until the cost estimate is finished.
+func NewCostEstimateReportRow(workspace *tfe.Workspace, runID string, ce *tfe.CostEstimate) (CostEstimateReportRow, error) {
+	row := CostEstimateReportRow{
+		Workspace:      workspace.Name,
+		WorkspaceID:    workspace.ID,
+		RunID:          runID,
+		CostEstimateID: ce.ID,
+		Status:         ce.Status,
+	}
+
+	if ce.Status != tfe.CostEstimateFinished {
+		return row, nil
+	}
+
+	costs := []struct {
+		value string
+		field *float64
+	}{
+		{ce.PriorMonthlyCost, &row.PriorMonthlyCost},
+		{ce.ProposedMonthlyCost, &row.ProposedMonthlyCost},
+		{ce.DeltaMonthlyCost, &row.DeltaMonthlyCost},
+	}
+
+	for _, c := range costs {
+		if c.value == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(c.value, 64)
+		if err != nil {
+			return row, fmt.Errorf("invalid monthly cost %q in cost estimate %s\n%v", c.value, ce.ID, err)
+		}
+		*c.field = f
+	}
+
+	return row, nil
+}
+
+// NewCostEstimateReport returns the report of the given rows along with the organization total
+func NewCostEstimateReport(organization string, rows []CostEstimateReportRow) *CostEstimateReport {
+	report := &CostEstimateReport{Organization: organization, Workspaces: rows}
+
+	for _, row := range rows {
+		if row.Status != tfe.CostEstimateFinished {
+			continue
+		}
+
+		report.Total.Workspaces++
+		report.Total.PriorMonthlyCost += row.PriorMonthlyCost
+		report.Total.ProposedMonthlyCost += row.ProposedMonthlyCost
+		report.Total.DeltaMonthlyCost += row.DeltaMonthlyCost
+	}
+
+	return report
+}
+
+// RenderCostEstimateReport writes the report into w using the given format, the last line holds the organization total
+func RenderCostEstimateReport(w io.Writer, report *CostEstimateReport, format string) error {
+	switch format {
+	case CostEstimateReportTable:
+		return renderCostEstimateReportTable(w, report)
+	case CostEstimateReportCSV:
+		return renderCostEstimateReportCSV(w, report)
+	}
+
+	return ValidateCostEstimateReportFormat(format)
+}
+
+func renderCostEstimateReportTable(w io.Writer, report *CostEstimateReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "WORKSPACE\tSTATUS\tPRIOR MONTHLY COST\tPROPOSED MONTHLY COST\tDELTA")
+
+	for _, row := range report.Workspaces {
+		if row.Status != tfe.CostEstimateFinished {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\n", row.Workspace, row.Status)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%.2f\t%+.2f\n", row.Workspace, row.Status, row.PriorMonthlyCost, row.ProposedMonthlyCost, row.DeltaMonthlyCost)
+	}
+
+	t := report.Total
+	fmt.Fprintf(tw, "TOTAL (%d workspaces)\t\t%.2f\t%.2f\t%+.2f\n", t.Workspaces, t.PriorMonthlyCost, t.ProposedMonthlyCost, t.DeltaMonthlyCost)
+
+	return tw.Flush()
+}
+
+func renderCostEstimateReportCSV(w io.Writer, report *CostEstimateReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"workspace", "workspace_id", "run_id", "cost_estimate_id", "status", "prior_monthly_cost", "proposed_monthly_cost", "delta_monthly_cost"})
+
+	cost := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+
+	for _, row := range report.Workspaces {
+		record := []string{row.Workspace, row.WorkspaceID, row.RunID, row.CostEstimateID, string(row.Status), "", "", ""}
+		if row.Status == tfe.CostEstimateFinished {
+			record[5], record[6], record[7] = cost(row.PriorMonthlyCost), cost(row.ProposedMonthlyCost), cost(row.DeltaMonthlyCost)
+		}
+		cw.Write(record)
+	}
+
+	t := report.Total
+	cw.Write([]string{"TOTAL", "", "", "", "", cost(t.PriorMonthlyCost), cost(t.ProposedMonthlyCost), cost(t.DeltaMonthlyCost)})
+
+	cw.Flush()
+	return cw.Error()
+}
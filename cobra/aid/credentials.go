@@ -0,0 +1,249 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/viper"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/model"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/yaml.v2"
+)
+
+// Credential backends, set per profile
+const (
+	CredentialBackendPlain     = "plain"
+	CredentialBackendEncrypted = "encrypted"
+)
+
+// scrypt parameters recommended for interactive logins, and AES-256 key size
+const (
+	scryptN      = 32768
+	scryptR      = 8
+	scryptP      = 1
+	keyLength    = 32
+	saltLength   = 16
+	cipherPrefix = "scrypt-aes-gcm:"
+)
+
+// passphrases are asked once per profile and execution
+var passphrases = map[string]string{}
+
+// credentialSecrets are the fields of a profile stored encrypted
+type credentialSecrets struct {
+	UserToken         string `yaml:"userToken,omitempty"`
+	TeamToken         string `yaml:"teamToken,omitempty"`
+	OrganizationToken string `yaml:"organizationToken,omitempty"`
+}
+
+// IsEncryptedCredentialProfile returns true if the profile uses the encrypted backend
+func IsEncryptedCredentialProfile(cp model.CredentialProfile) bool {
+	return cp.Backend == CredentialBackendEncrypted
+}
+
+// GetCredentialsPassphrase returns the passphrase of the profile from TFC_CREDENTIALS_PASSPHRASE, or asks the user for it
+func GetCredentialsPassphrase(name string) (string, error) {
+	if p, ok := passphrases[name]; ok {
+		return p, nil
+	}
+
+	if p := viper.GetString("CREDENTIALS_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+
+	p, err := readPassphrase(fmt.Sprintf("credentials passphrase of profile %s: ", name))
+	if err != nil {
+		return "", err
+	}
+
+	passphrases[name] = p
+	return p, nil
+}
+
+// GetNewCredentialsPassphrase is like GetCredentialsPassphrase, but asks the user to type a new passphrase twice
+func GetNewCredentialsPassphrase(name string) (string, error) {
+	if _, ok := passphrases[name]; ok || viper.GetString("CREDENTIALS_PASSPHRASE") != "" {
+		return GetCredentialsPassphrase(name)
+	}
+
+	p, err := readPassphrase(fmt.Sprintf("new credentials passphrase of profile %s: ", name))
+	if err != nil {
+		return "", err
+	}
+
+	confirmation, err := readPassphrase(fmt.Sprintf("confirm credentials passphrase of profile %s: ", name))
+	if err != nil {
+		return "", err
+	}
+
+	if p != confirmation {
+		return "", fmt.Errorf("passphrases don't match")
+	}
+
+	passphrases[name] = p
+	return p, nil
+}
+
+// readPassphrase asks the user for a passphrase without echoing it
+func readPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return "", fmt.Errorf("credentials are encrypted, set TFC_CREDENTIALS_PASSPHRASE or run tecli from a terminal")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("unable to read passphrase\n%v", err)
+	}
+
+	if len(b) == 0 {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+
+	return string(b), nil
+}
+
+// EncryptCredentialProfile moves the tokens of the profile into its ciphertext
+func EncryptCredentialProfile(cp model.CredentialProfile, passphrase string) (model.CredentialProfile, error) {
+	secrets := credentialSecrets{
+		UserToken:         cp.UserToken,
+		TeamToken:         cp.TeamToken,
+		OrganizationToken: cp.OrganizationToken,
+	}
+
+	plaintext, err := yaml.Marshal(secrets)
+	if err != nil {
+		return cp, fmt.Errorf("unable to encode profile %s secrets\n%v", cp.Name, err)
+	}
+
+	ciphertext, err := Encrypt(plaintext, passphrase)
+	if err != nil {
+		return cp, fmt.Errorf("unable to encrypt profile %s\n%v", cp.Name, err)
+	}
+
+	cp.Backend = CredentialBackendEncrypted
+	cp.Ciphertext = ciphertext
+	cp.UserToken = ""
+	cp.TeamToken = ""
+	cp.OrganizationToken = ""
+
+	return cp, nil
+}
+
+// DecryptCredentialProfile restores the tokens of the profile from its ciphertext
+func DecryptCredentialProfile(cp model.CredentialProfile, passphrase string) (model.CredentialProfile, error) {
+	plaintext, err := Decrypt(cp.Ciphertext, passphrase)
+	if err != nil {
+		return cp, fmt.Errorf("unable to decrypt profile %s\n%v", cp.Name, err)
+	}
+
+	var secrets credentialSecrets
+	if err := yaml.Unmarshal(plaintext, &secrets); err != nil {
+		return cp, fmt.Errorf("unable to decode profile %s secrets\n%v", cp.Name, err)
+	}
+
+	cp.UserToken = secrets.UserToken
+	cp.TeamToken = secrets.TeamToken
+	cp.OrganizationToken = secrets.OrganizationToken
+	cp.Ciphertext = ""
+
+	return cp, nil
+}
+
+// Encrypt seals the plaintext with AES-GCM, using a key derived from the passphrase with scrypt.
+// The result is base64 encoded, and contains the salt and nonce needed to decrypt it.
+func Encrypt(plaintext []byte, passphrase string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", fmt.Errorf("unable to generate salt\n%v", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("unable to generate nonce\n%v", err)
+	}
+
+	sealed := gcm.Seal(nil, nonce, plaintext, nil)
+
+	data := make([]byte, 0, len(salt)+len(nonce)+len(sealed))
+	data = append(data, salt...)
+	data = append(data, nonce...)
+	data = append(data, sealed...)
+
+	return cipherPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt opens a ciphertext created by Encrypt
+func Decrypt(ciphertext string, passphrase string) ([]byte, error) {
+	if len(ciphertext) < len(cipherPrefix) || ciphertext[:len(cipherPrefix)] != cipherPrefix {
+		return nil, fmt.Errorf("unknown ciphertext format")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(ciphertext[len(cipherPrefix):])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode ciphertext\n%v", err)
+	}
+
+	if len(data) < saltLength {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+
+	gcm, err := newGCM(passphrase, data[:saltLength])
+	if err != nil {
+		return nil, err
+	}
+
+	data = data[saltLength:]
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or corrupted ciphertext")
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key from passphrase\n%v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher\n%v", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// DeployOptions controls how a deploy is performed
+type DeployOptions struct {
+	Path      string
+	Message   string
+	IsDestroy bool
+	AutoApply bool
+	Comment   string
+	Wait      RunWaitOptions
+}
+
+// SetDeployFlags define flags for the cobra command
+func SetDeployFlags(cmd *cobra.Command) {
+	usage := `The workspace ID to deploy to.`
+	cmd.Flags().String("workspace-id", "", usage)
+
+	SetWorkspaceLookupFlags(cmd)
+
+	usage = `The directory containing the Terraform configuration files to upload.`
+	cmd.Flags().String("path", ".", usage)
+
+	usage = `Specifies the message to be associated with the run.`
+	cmd.Flags().String("message", "", usage)
+
+	usage = `Specifies if the run is a destroy plan, which will destroy all provisioned resources.`
+	cmd.Flags().Bool("is-destroy", false, usage)
+
+	usage = `Apply the run once it's planned and confirmable. Implies --wait.`
+	cmd.Flags().Bool("auto-apply", false, usage)
+
+	usage = `An optional comment about the apply, used with --auto-apply.`
+	cmd.Flags().String("comment", "", usage)
+
+	usage = `Wait until the run reaches a final status, or until it needs confirmation. Exit codes: 3 errored, 4 canceled, 5 discarded, 6 policy_soft_failed, 7 timeout, 10 needs confirmation.`
+	cmd.Flags().Bool("wait", false, usage)
+
+	usage = `The maximum time to wait for each step, the upload and the run, example: 30m. Zero means wait indefinitely.`
+	cmd.Flags().Duration("timeout", 0, usage)
+
+	usage = `How often the run status is checked while waiting.`
+	cmd.Flags().Duration("poll-interval", 5*time.Second, usage)
+}
+
+// GetDeployOptions return options based on the command's flags value
+func GetDeployOptions(cmd *cobra.Command) DeployOptions {
+	var options DeployOptions
+
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		logrus.Fatalf("unable to get flag path\n%v", err)
+	}
+
+	options.Path = path
+
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		logrus.Fatalf("unable to get flag message\n%v", err)
+	}
+
+	options.Message = message
+
+	isDestroy, err := cmd.Flags().GetBool("is-destroy")
+	if err != nil {
+		logrus.Fatalf("unable to get flag is-destroy\n%v", err)
+	}
+
+	options.IsDestroy = isDestroy
+
+	autoApply, err := cmd.Flags().GetBool("auto-apply")
+	if err != nil {
+		logrus.Fatalf("unable to get flag auto-apply\n%v", err)
+	}
+
+	options.AutoApply = autoApply
+
+	comment, err := cmd.Flags().GetString("comment")
+	if err != nil {
+		logrus.Fatalf("unable to get flag comment\n%v", err)
+	}
+
+	options.Comment = comment
+
+	options.Wait = GetRunWaitOptions(cmd)
+	if autoApply {
+		options.Wait.Wait = true
+	}
+
+	return options
+}
@@ -2,8 +2,10 @@ package aid
 
 import (
 	"encoding/json"
+	"os"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 )
 
 // ToJSON converts a given struct to json
@@ -15,3 +17,87 @@ func ToJSON(v interface{}) string {
 
 	return string(b)
 }
+
+// getStringFlagIfChanged returns nil if the flag wasn't set, so the attribute isn't sent
+func getStringFlagIfChanged(cmd *cobra.Command, flag string) *string {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+
+	value, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		logrus.Fatalf("unable to get flag %s\n%v", flag, err)
+	}
+
+	return &value
+}
+
+// getIntFlagIfChanged returns nil if the flag wasn't set, so the attribute isn't sent
+func getIntFlagIfChanged(cmd *cobra.Command, flag string) *int {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+
+	value, err := cmd.Flags().GetInt(flag)
+	if err != nil {
+		logrus.Fatalf("unable to get flag %s\n%v", flag, err)
+	}
+
+	return &value
+}
+
+// getBoolFlagIfChanged returns nil if the flag wasn't set, so the attribute isn't sent
+func getBoolFlagIfChanged(cmd *cobra.Command, flag string) *bool {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+
+	value, err := cmd.Flags().GetBool(flag)
+	if err != nil {
+		logrus.Fatalf("unable to get flag %s\n%v", flag, err)
+	}
+
+	return &value
+}
+
+// OpenSecretFile opens path for writing, creating it if needed, readable by the current user only.
+// The permissions of an existing file are restricted right away, its content is kept until WriteSecret.
+func OpenSecretFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// WriteSecret replaces the content of a file opened with OpenSecretFile by data, and closes it.
+func WriteSecret(f *os.File, data []byte) error {
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// WriteSecretFile writes data into path, readable by the current user only.
+// The permissions of an existing file are restricted before anything is written into it.
+func WriteSecretFile(path string, data []byte) error {
+	f, err := OpenSecretFile(path)
+	if err != nil {
+		return err
+	}
+
+	return WriteSecret(f, data)
+}
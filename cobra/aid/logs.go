@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Terraform frames its remote logs with STX (start of text) and ETX (end of text) markers
+const (
+	startOfText = byte(2)
+	endOfText   = byte(3)
+	escape      = byte(27)
+)
+
+// LogsOptions controls how plan and apply logs are displayed
+type LogsOptions struct {
+	Follow  bool
+	NoColor bool
+}
+
+// SetLogsFlags define flags for the logs argument
+func SetLogsFlags(cmd *cobra.Command) {
+	usage := `Stream the logs as they are produced, until the plan or apply finishes.`
+	cmd.Flags().Bool("follow", false, usage)
+
+	usage = `Remove ANSI colors from the logs.`
+	cmd.Flags().Bool("no-color", false, usage)
+}
+
+// GetLogsOptions return options based on the command's flags value
+func GetLogsOptions(cmd *cobra.Command) LogsOptions {
+	var options LogsOptions
+
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		logrus.Fatalf("unable to get flag follow\n%v", err)
+	}
+
+	options.Follow = follow
+
+	noColor, err := cmd.Flags().GetBool("no-color")
+	if err != nil {
+		logrus.Fatalf("unable to get flag no-color\n%v", err)
+	}
+
+	options.NoColor = noColor
+
+	return options
+}
+
+// LogWriter removes Terraform's STX/ETX markers and, optionally, ANSI escape sequences before writing.
+// Escape sequences split across writes are handled, so it's safe to use with streamed chunks.
+type LogWriter struct {
+	w       io.Writer
+	noColor bool
+	state   int
+}
+
+// escape sequence parsing states
+const (
+	stateText = iota
+	stateEscape
+	stateControlSequence
+)
+
+// NewLogWriter returns a new LogWriter writing to w
+func NewLogWriter(w io.Writer, noColor bool) *LogWriter {
+	return &LogWriter{w: w, noColor: noColor}
+}
+
+// Write filters p and writes the result into the underlying writer
+func (l *LogWriter) Write(p []byte) (int, error) {
+	out := make([]byte, 0, len(p))
+
+	for _, b := range p {
+		switch l.state {
+		case stateEscape:
+			// ESC [ starts a control sequence, any other character ends a two characters sequence
+			if b == '[' {
+				l.state = stateControlSequence
+			} else {
+				l.state = stateText
+			}
+			continue
+
+		case stateControlSequence:
+			// control sequences end with a byte in the range @ to ~
+			if b >= 0x40 && b <= 0x7e {
+				l.state = stateText
+			}
+			continue
+		}
+
+		switch {
+		case b == startOfText, b == endOfText:
+			continue
+		case b == escape && l.noColor:
+			l.state = stateEscape
+			continue
+		}
+
+		out = append(out, b)
+	}
+
+	if _, err := l.w.Write(out); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var notificationTriggers = []string{
+	tfe.NotificationTriggerCreated,
+	tfe.NotificationTriggerPlanning,
+	tfe.NotificationTriggerNeedsAttention,
+	tfe.NotificationTriggerApplying,
+	tfe.NotificationTriggerCompleted,
+	tfe.NotificationTriggerErrored,
+}
+
+// SetNotificationFlags define flags for the cobra command
+func SetNotificationFlags(cmd *cobra.Command) {
+	usage := `The notification configuration ID. Required for read, update, delete and verify.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The workspace ID.`
+	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
+
+	usage = `The name of the notification configuration.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `The type of the destination. Valid values: generic, slack or email.`
+	cmd.Flags().String("destination-type", "", usage)
+
+	usage = `The URL notifications are sent to. Required for generic and slack destinations.`
+	cmd.Flags().String("url", "", usage)
+
+	usage = `A secret used to sign the payload of generic notifications with HMAC.`
+	cmd.Flags().String("token", "", usage)
+
+	usage = fmt.Sprintf(`A run event that triggers a notification, can be repeated. Valid values: %s.`, strings.Join(notificationTriggers, ", "))
+	cmd.Flags().StringArray("trigger", []string{}, usage)
+
+	usage = `Whether the notification configuration is enabled.`
+	cmd.Flags().Bool("enabled", true, usage)
+
+	usage = `An email address that receives the notifications of an email destination, can be repeated. Terraform Enterprise only.`
+	cmd.Flags().StringArray("email-address", []string{}, usage)
+
+	usage = `The ID of an organization user that receives the notifications of an email destination, can be repeated.`
+	cmd.Flags().StringArray("email-user-id", []string{}, usage)
+
+	// Bulk create
+	usage = `Create the notification configuration on every workspace whose name contains this string, within --organization.`
+	cmd.Flags().String("search", "", usage)
+
+	usage = `Create the notification configuration on every workspace listed in this file, one name per line, within --organization.`
+	cmd.Flags().String("workspaces-file", "", usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// ValidateNotificationFlags returns an error if the destination type or the triggers are invalid, or a destination misses its URL.
+// The destination type is only required on create.
+func ValidateNotificationFlags(cmd *cobra.Command, create bool) error {
+	destinationType, err := cmd.Flags().GetString("destination-type")
+	if err != nil {
+		return fmt.Errorf("unable to get flag destination-type\n%v", err)
+	}
+
+	if create {
+		switch tfe.NotificationDestinationType(destinationType) {
+		case "":
+			return fmt.Errorf("--destination-type must be defined")
+		case tfe.NotificationDestinationTypeGeneric, tfe.NotificationDestinationTypeSlack:
+			if url, _ := cmd.Flags().GetString("url"); url == "" {
+				return fmt.Errorf("--url must be defined for %s destinations", destinationType)
+			}
+		case tfe.NotificationDestinationTypeEmail:
+		default:
+			return fmt.Errorf("invalid value for --destination-type: %s\nvalid values: generic, slack or email", destinationType)
+		}
+	}
+
+	triggers, err := cmd.Flags().GetStringArray("trigger")
+	if err != nil {
+		return fmt.Errorf("unable to get flag trigger\n%v", err)
+	}
+
+	for _, t := range triggers {
+		if !containsString(notificationTriggers, t) {
+			return fmt.Errorf("invalid value for --trigger: %s\nvalid values: %s", t, strings.Join(notificationTriggers, ", "))
+		}
+	}
+
+	return nil
+}
+
+// GetNotificationCreateOptions return options based on the flags values
+func GetNotificationCreateOptions(cmd *cobra.Command) tfe.NotificationConfigurationCreateOptions {
+	var options tfe.NotificationConfigurationCreateOptions
+
+	destinationType, err := cmd.Flags().GetString("destination-type")
+	if err != nil {
+		logrus.Fatalf("unable to get flag destination-type\n%v", err)
+	}
+
+	dt := tfe.NotificationDestinationType(destinationType)
+	options.DestinationType = &dt
+
+	enabled, err := cmd.Flags().GetBool("enabled")
+	if err != nil {
+		logrus.Fatalf("unable to get flag enabled\n%v", err)
+	}
+
+	options.Enabled = &enabled
+	options.Name = getStringFlagIfChanged(cmd, "name")
+	options.URL = getStringFlagIfChanged(cmd, "url")
+	options.Token = getStringFlagIfChanged(cmd, "token")
+	options.Triggers, options.EmailAddresses, options.EmailUsers = getNotificationLists(cmd)
+
+	return options
+}
+
+// GetNotificationUpdateOptions return options based on the flags values, only the flags given are updated
+func GetNotificationUpdateOptions(cmd *cobra.Command) tfe.NotificationConfigurationUpdateOptions {
+	var options tfe.NotificationConfigurationUpdateOptions
+
+	options.Enabled = getBoolFlagIfChanged(cmd, "enabled")
+	options.Name = getStringFlagIfChanged(cmd, "name")
+	options.URL = getStringFlagIfChanged(cmd, "url")
+	options.Token = getStringFlagIfChanged(cmd, "token")
+
+	// lists left out aren't changed, an empty one would clear them
+	triggers, emailAddresses, emailUsers := getNotificationLists(cmd)
+	if cmd.Flags().Changed("trigger") {
+		options.Triggers = triggers
+	}
+
+	if cmd.Flags().Changed("email-address") {
+		options.EmailAddresses = emailAddresses
+	}
+
+	if cmd.Flags().Changed("email-user-id") {
+		options.EmailUsers = emailUsers
+	}
+
+	return options
+}
+
+func getNotificationLists(cmd *cobra.Command) (triggers []string, emailAddresses []string, emailUsers []*tfe.User) {
+	triggers, err := cmd.Flags().GetStringArray("trigger")
+	if err != nil {
+		logrus.Fatalf("unable to get flag trigger\n%v", err)
+	}
+
+	emailAddresses, err = cmd.Flags().GetStringArray("email-address")
+	if err != nil {
+		logrus.Fatalf("unable to get flag email-address\n%v", err)
+	}
+
+	userIDs, err := cmd.Flags().GetStringArray("email-user-id")
+	if err != nil {
+		logrus.Fatalf("unable to get flag email-user-id\n%v", err)
+	}
+
+	for _, id := range userIDs {
+		emailUsers = append(emailUsers, &tfe.User{ID: id})
+	}
+
+	return triggers, emailAddresses, emailUsers
+}
+
+// ReadWorkspaceNamesFile reads a list of workspace names, one per line
+func ReadWorkspaceNamesFile(path string) ([]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file %s\n%v", path, err)
+	}
+
+	return ParseWorkspaceNames(b), nil
+}
+
+// ParseWorkspaceNames returns the workspace names, one per line, ignoring blank lines and # comments
+func ParseWorkspaceNames(b []byte) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		name := strings.TrimSpace(line)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// PrintNotificationList renders the list items using the given output format
+func PrintNotificationList(list *tfe.NotificationConfigurationList, format string) {
+	PrintOutput(format, list.Items)
+}
@@ -16,8 +16,6 @@ limitations under the License.
 package aid
 
 import (
-	"fmt"
-
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -37,6 +35,8 @@ func SetOAuthClientFlags(cmd *cobra.Command) {
 	cmd.Flags().String("private-key", "", usage)
 	usage = `The VCS provider being connected with. Valid values azure-devops-server, azure-devops-services, bitbucket-hosted, bitbucket-server, bitbucket-server-legacy, github, github-enterprise, gitlab-hosted, gitlab-community-edition, gitlab-enterprise-edition.`
 	cmd.Flags().String("service-provider", "", usage)
+
+	SetPaginationFlags(cmd)
 }
 
 // GetOAuthClientCreateOptions return options based on the flags values
@@ -129,15 +129,7 @@ func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOption
 	return options
 }
 
-// PrintOAuthClientList TODO ...
-func PrintOAuthClientList(list *tfe.OAuthClientList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
-	}
+// PrintOAuthClientList renders the list items using the given output format
+func PrintOAuthClientList(list *tfe.OAuthClientList, format string) {
+	PrintOutput(format, list.Items)
 }
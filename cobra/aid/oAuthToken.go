@@ -16,8 +16,6 @@ limitations under the License.
 package aid
 
 import (
-	"fmt"
-
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -30,6 +28,8 @@ func SetOAuthTokenFlags(cmd *cobra.Command) {
 
 	usage = `A private SSH key to be used for git clone operations.`
 	cmd.Flags().String("private-ssh-key", "", usage)
+
+	SetPaginationFlags(cmd)
 }
 
 // GetOAuthTokenUpdateOptions return options based on the flag values
@@ -50,15 +50,7 @@ func GetOAuthTokenUpdateOptions(cmd *cobra.Command) tfe.OAuthTokenUpdateOptions
 
 }
 
-// PrintOAuthTokenList TODO ...
-func PrintOAuthTokenList(list *tfe.OAuthTokenList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
-	}
+// PrintOAuthTokenList renders the list items using the given output format
+func PrintOAuthTokenList(list *tfe.OAuthTokenList, format string) {
+	PrintOutput(format, list.Items)
 }
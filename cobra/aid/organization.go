@@ -0,0 +1,105 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetOrganizationFlags define flags for the cobra command
+func SetOrganizationFlags(cmd *cobra.Command) {
+	usage := `Name of the organization to create.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `A new name for the organization.`
+	cmd.Flags().String("new-name", "", usage)
+
+	usage = `Admin email address.`
+	cmd.Flags().String("email", "", usage)
+
+	usage = `Session expiration (minutes).`
+	cmd.Flags().Int("session-remember", 0, usage)
+
+	usage = `Session timeout after inactivity (minutes).`
+	cmd.Flags().Int("session-timeout", 0, usage)
+
+	usage = `Authentication policy. Valid values: password or two_factor_mandatory.`
+	cmd.Flags().String("collaborator-auth-policy", "", usage)
+
+	usage = `Whether or not the cost estimation feature is enabled for all workspaces in the organization.`
+	cmd.Flags().Bool("cost-estimation-enabled", false, usage)
+
+	usage = `The SAML role name of the owners team, used to assign users to it via SAML.`
+	cmd.Flags().String("owners-team-saml-role-id", "", usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// GetOrganizationCreateOptions return options based on the flags values
+func GetOrganizationCreateOptions(cmd *cobra.Command) tfe.OrganizationCreateOptions {
+	var options tfe.OrganizationCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag name\n%v", err)
+	}
+
+	if name != "" {
+		options.Name = &name
+	}
+
+	options.Email = getStringFlagIfChanged(cmd, "email")
+	options.SessionRemember = getIntFlagIfChanged(cmd, "session-remember")
+	options.SessionTimeout = getIntFlagIfChanged(cmd, "session-timeout")
+	options.CollaboratorAuthPolicy = getOrganizationAuthPolicy(cmd)
+	options.CostEstimationEnabled = getBoolFlagIfChanged(cmd, "cost-estimation-enabled")
+	options.OwnersTeamSAMLRoleID = getStringFlagIfChanged(cmd, "owners-team-saml-role-id")
+
+	return options
+}
+
+// GetOrganizationUpdateOptions return options based on the flags values
+func GetOrganizationUpdateOptions(cmd *cobra.Command) tfe.OrganizationUpdateOptions {
+	var options tfe.OrganizationUpdateOptions
+
+	options.Name = getStringFlagIfChanged(cmd, "new-name")
+	options.Email = getStringFlagIfChanged(cmd, "email")
+	options.SessionRemember = getIntFlagIfChanged(cmd, "session-remember")
+	options.SessionTimeout = getIntFlagIfChanged(cmd, "session-timeout")
+	options.CollaboratorAuthPolicy = getOrganizationAuthPolicy(cmd)
+	options.CostEstimationEnabled = getBoolFlagIfChanged(cmd, "cost-estimation-enabled")
+	options.OwnersTeamSAMLRoleID = getStringFlagIfChanged(cmd, "owners-team-saml-role-id")
+
+	return options
+}
+
+// PrintOrganizationList renders the list items using the given output format
+func PrintOrganizationList(list *tfe.OrganizationList, format string) {
+	PrintOutput(format, list.Items)
+}
+
+func getOrganizationAuthPolicy(cmd *cobra.Command) *tfe.AuthPolicyType {
+	value := getStringFlagIfChanged(cmd, "collaborator-auth-policy")
+	if value == nil {
+		return nil
+	}
+
+	policy := tfe.AuthPolicyType(*value)
+	return &policy
+}
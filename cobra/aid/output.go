@@ -0,0 +1,297 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats supported by the --output flag
+const (
+	OutputJSON             = "json"
+	OutputYAML             = "yaml"
+	OutputTable            = "table"
+	OutputJSONPathPrefix   = "jsonpath="
+	OutputGoTemplatePrefix = "go-template="
+)
+
+// OutputUsage describes the valid values of the --output flag
+const OutputUsage = `Output format. Valid values: json, yaml, table, jsonpath=<template> or go-template=<template>.`
+
+// tableColumns are the default columns displayed by the table output, per resource type
+var tableColumns = map[string][]string{
+	"AgentPool":                 {"ID", "Name"},
+	"AgentToken":                {"ID", "Description", "CreatedAt", "LastUsedAt"},
+	"Apply":                     {"ID", "Status", "ResourceAdditions", "ResourceChanges", "ResourceDestructions"},
+	"ConfigurationVersion":      {"ID", "Status", "Source", "Speculative", "AutoQueueRuns"},
+	"CostEstimate":              {"ID", "Status", "PriorMonthlyCost", "ProposedMonthlyCost", "DeltaMonthlyCost"},
+	"CredentialProfile":         {"Name", "Description", "Enabled", "Address", "UpdatedAt"},
+	"NotificationConfiguration": {"ID", "Name", "DestinationType", "Enabled", "URL"},
+	"OAuthClient":               {"ID", "ServiceProvider", "HTTPURL", "CreatedAt"},
+	"OAuthToken":                {"ID", "ServiceProviderUser", "HasSSHKey", "CreatedAt"},
+	"Organization":              {"Name", "Email", "CollaboratorAuthPolicy", "CostEstimationEnabled", "CreatedAt"},
+	"Plan":                      {"ID", "Status", "HasChanges", "ResourceAdditions", "ResourceChanges", "ResourceDestructions"},
+	"Policy":                    {"ID", "Name", "Description", "PolicySetCount", "UpdatedAt"},
+	"PolicyCheck":               {"ID", "Scope", "Status"},
+	"PolicySet":                 {"ID", "Name", "Global", "PolicyCount", "WorkspaceCount", "UpdatedAt"},
+	"RegistryModule":            {"ID", "Name", "Provider", "Status", "UpdatedAt"},
+	"RegistryModuleVersion":     {"ID", "Version", "Status", "Source", "CreatedAt"},
+	"Run":                       {"ID", "Status", "Message", "IsDestroy", "HasChanges", "CreatedAt"},
+	"RunTrigger":                {"ID", "SourceableName", "WorkspaceName", "CreatedAt"},
+	"SSHKey":                    {"ID", "Name"},
+	"StateVersion":              {"ID", "Serial", "VCSCommitSHA", "CreatedAt"},
+	"StateVersionOutput":        {"Name", "Type", "Sensitive", "Value"},
+	"Team":                      {"ID", "Name", "Visibility", "UserCount"},
+	"TeamAccess":                {"ID", "Access", "Runs", "Variables", "StateVersions", "SentinelMocks", "WorkspaceLocking"},
+	"User":                      {"ID", "Username", "Email", "IsServiceAccount"},
+	"Variable":                  {"ID", "Key", "Value", "Category", "HCL", "Sensitive"},
+	"Workspace":                 {"ID", "Name", "TerraformVersion", "ExecutionMode", "AutoApply", "Locked"},
+}
+
+// ValidateOutputFormat returns an error if the given output format is not supported
+func ValidateOutputFormat(format string) error {
+	switch {
+	case format == OutputJSON, format == OutputYAML, format == OutputTable:
+		return nil
+	case strings.HasPrefix(format, OutputJSONPathPrefix):
+		return helper.ValidateJSONPath(strings.TrimPrefix(format, OutputJSONPathPrefix))
+	case strings.HasPrefix(format, OutputGoTemplatePrefix):
+		_, err := template.New("output").Parse(strings.TrimPrefix(format, OutputGoTemplatePrefix))
+		if err != nil {
+			return fmt.Errorf("invalid go-template\n%v", err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown output format: %s\n%s", format, OutputUsage)
+}
+
+// PrintOutput renders the given value on the standard output using the given format
+func PrintOutput(format string, v interface{}) {
+	if err := RenderOutput(os.Stdout, format, v); err != nil {
+		logrus.Fatalf("unable to render output\n%v", err)
+	}
+}
+
+// RenderOutput writes the given value into w using the given format
+func RenderOutput(w io.Writer, format string, v interface{}) error {
+	v = emptyIfNilSlice(v)
+
+	switch {
+	case format == "", format == OutputJSON:
+		_, err := fmt.Fprintln(w, ToJSON(v))
+		return err
+
+	case format == OutputYAML:
+		return renderYAML(w, v)
+
+	case format == OutputTable:
+		return renderTable(w, v)
+
+	case strings.HasPrefix(format, OutputJSONPathPrefix):
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("unable to convert struct to json\n%v", err)
+		}
+
+		out, err := helper.EvaluateJSONPath(strings.TrimPrefix(format, OutputJSONPathPrefix), b)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(w, out)
+		return err
+
+	case strings.HasPrefix(format, OutputGoTemplatePrefix):
+		t, err := template.New("output").Parse(strings.TrimPrefix(format, OutputGoTemplatePrefix))
+		if err != nil {
+			return fmt.Errorf("invalid go-template\n%v", err)
+		}
+
+		if err := t.Execute(w, v); err != nil {
+			return fmt.Errorf("unable to execute go-template\n%v", err)
+		}
+
+		_, err = fmt.Fprintln(w)
+		return err
+	}
+
+	return fmt.Errorf("unknown output format: %s\n%s", format, OutputUsage)
+}
+
+// renderYAML converts the value to JSON first, so field names are the same across formats
+func renderYAML(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to convert struct to json\n%v", err)
+	}
+
+	// JSON is valid YAML, so it can be decoded straight away
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("unable to decode json as yaml\n%v", err)
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("unable to convert struct to yaml\n%v", err)
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+func renderTable(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to convert struct to json\n%v", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("unable to decode json\n%v", err)
+	}
+
+	var rows []map[string]interface{}
+	switch d := doc.(type) {
+	case []interface{}:
+		for _, item := range d {
+			if row, ok := item.(map[string]interface{}); ok {
+				rows = append(rows, row)
+			}
+		}
+	case map[string]interface{}:
+		rows = append(rows, d)
+	default:
+		_, err := fmt.Fprintln(w, helper.FormatJSONValue(d))
+		return err
+	}
+
+	columns := getTableColumns(v, rows)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	headers := make([]string, 0, len(columns))
+	for _, c := range columns {
+		headers = append(headers, toColumnHeader(c))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, c := range columns {
+			values = append(values, helper.FormatJSONValue(row[c]))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// getTableColumns return the default columns of the resource type, or every scalar field of the first row otherwise
+func getTableColumns(v interface{}, rows []map[string]interface{}) []string {
+	if columns, found := tableColumns[getResourceTypeName(v)]; found {
+		return columns
+	}
+
+	var columns []string
+	if len(rows) == 0 {
+		return columns
+	}
+
+	// keep the struct order when possible
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+
+	if t != nil && t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			name := t.Field(i).Name
+			if isScalar(rows[0][name]) {
+				columns = append(columns, name)
+			}
+		}
+		return columns
+	}
+
+	for name, value := range rows[0] {
+		if isScalar(value) {
+			columns = append(columns, name)
+		}
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+func getResourceTypeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return ""
+	}
+
+	return t.Name()
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
+
+// toColumnHeader converts a field name into a table header, example: TerraformVersion becomes TERRAFORM VERSION
+func toColumnHeader(name string) string {
+	var buf bytes.Buffer
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+			buf.WriteRune(' ')
+		}
+		buf.WriteRune(unicode.ToUpper(r))
+	}
+
+	return buf.String()
+}
+
+// emptyIfNilSlice avoids rendering nil slices as null, lists should always be rendered as arrays
+func emptyIfNilSlice(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+
+	return v
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// maxPageSize is the maximum number of items the API returns in a single page
+const maxPageSize = 100
+
+// PaginationOptions controls how list commands walk through the pages returned by the API
+type PaginationOptions struct {
+	// Page requests a single page. When zero, every page is fetched.
+	Page int
+	// PageSize is the number of items requested per page. When zero, the API default is used.
+	PageSize int
+	// Limit is the maximum number of items to return. When zero, there's no limit.
+	Limit int
+}
+
+// ListPage fetches a single page given the list options, returning the pagination details and the number of items received
+type ListPage func(options tfe.ListOptions) (*tfe.Pagination, int, error)
+
+// SetPaginationFlags define pagination flags for the cobra command
+func SetPaginationFlags(cmd *cobra.Command) {
+	usage := `The page number to request. If omitted, all pages are fetched.`
+	cmd.Flags().Int("page", 0, usage)
+
+	usage = `The number of items returned in a single page. Maximum 100.`
+	cmd.Flags().Int("page-size", 0, usage)
+
+	usage = `The maximum number of items to return. If omitted, all items are returned.`
+	cmd.Flags().Int("limit", 0, usage)
+}
+
+// GetPaginationOptions return options based on the flags values
+func GetPaginationOptions(cmd *cobra.Command) PaginationOptions {
+	var options PaginationOptions
+
+	page, err := cmd.Flags().GetInt("page")
+	if err != nil {
+		logrus.Fatalf("unable to get flag page\n%v", err)
+	}
+
+	if page > 0 {
+		options.Page = page
+	}
+
+	pageSize, err := cmd.Flags().GetInt("page-size")
+	if err != nil {
+		logrus.Fatalf("unable to get flag page-size\n%v", err)
+	}
+
+	if pageSize > 0 {
+		options.PageSize = pageSize
+	}
+
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		logrus.Fatalf("unable to get flag limit\n%v", err)
+	}
+
+	if limit > 0 {
+		options.Limit = limit
+	}
+
+	return options
+}
+
+// Paginate calls fetch for every page until there's no next page or the limit is reached.
+// If a page was requested, only that page is fetched.
+func Paginate(options PaginationOptions, fetch ListPage) error {
+	listOptions := tfe.ListOptions{
+		PageNumber: options.Page,
+		PageSize:   options.PageSize,
+	}
+
+	// avoid fetching more items than necessary, unless a specific page was requested
+	if options.Page == 0 && options.Limit > 0 && options.Limit <= maxPageSize && (listOptions.PageSize == 0 || options.Limit < listOptions.PageSize) {
+		listOptions.PageSize = options.Limit
+	}
+
+	received := 0
+	for {
+		pagination, count, err := fetch(listOptions)
+		if err != nil {
+			return err
+		}
+		received += count
+
+		if options.Page > 0 || pagination == nil || pagination.NextPage == 0 {
+			return nil
+		}
+
+		if options.Limit > 0 && received >= options.Limit {
+			return nil
+		}
+
+		logrus.Debugf("fetching page %d of %d", pagination.NextPage, pagination.TotalPages)
+		listOptions.PageNumber = pagination.NextPage
+	}
+}
+
+// GetLimit return the number of items to keep from the given total
+func GetLimit(options PaginationOptions, total int) int {
+	if options.Limit > 0 && options.Limit < total {
+		return options.Limit
+	}
+
+	return total
+}
@@ -16,11 +16,129 @@ limitations under the License.
 package aid
 
 import (
+	"fmt"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// PlanExitChanges is the exit code of a speculative plan with changes, like terraform plan -detailed-exitcode
+const PlanExitChanges = 2
+
+// PlanExitMaxDestroy is the exit code of plan summary when the plan destroys more resources than allowed by --max-destroy
+const PlanExitMaxDestroy = 8
+
+// PlanCreateOptions controls how a speculative plan is created
+type PlanCreateOptions struct {
+	Speculative bool
+	Path        string
+	Wait        RunWaitOptions
+}
+
 // SetPlanFlags define flags for the cobra command
 func SetPlanFlags(cmd *cobra.Command) {
 	usage := `The Plan ID`
 	cmd.Flags().String("id", "", usage)
+
+	// Logs
+	SetLogsFlags(cmd)
+
+	// Create
+	usage = `The Workspace ID`
+	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
+
+	usage = `Create a speculative plan, which can't be applied. Required, plans that can be applied are created with run create.`
+	cmd.Flags().Bool("speculative", false, usage)
+
+	usage = `The directory containing the Terraform configuration files to plan. For export and json, the file the result is written to.`
+	cmd.Flags().String("path", ".", usage)
+
+	usage = `The maximum time to wait for the plan, or the plan export, to finish, example: 30m. For create, it covers every step, from the upload of the configuration files to the end of the plan. Zero means wait indefinitely.`
+	cmd.Flags().Duration("timeout", 0, usage)
+
+	usage = `How often the run, or the plan export, status is checked while waiting.`
+	cmd.Flags().Duration("poll-interval", 5*time.Second, usage)
+
+	// Summary
+	usage = `The maximum number of resources the plan may destroy, replaced ones included. Above it, summary exits with 8. Unlimited by default.`
+	cmd.Flags().Int("max-destroy", 0, usage)
+
+	// Check
+	usage = `The YAML file of the rules the plan's resource changes are checked against. Required for check.`
+	cmd.Flags().String("rules", "", usage)
+
+	usage = `A JSON execution plan, as plan json or terraform show -json write it, checked instead of downloading the plan given by --id.`
+	cmd.Flags().String("plan-file", "", usage)
+
+	// Export
+	usage = `The format of the plan export. Valid values: sentinel-mock-bundle-v0.`
+	cmd.Flags().String("format", string(tfe.PlanExportSentinelMockBundleV0), usage)
+}
+
+// ValidatePlanExportDataType returns an error if the given plan export format isn't supported
+func ValidatePlanExportDataType(dataType string) error {
+	switch tfe.PlanExportDataType(dataType) {
+	case tfe.PlanExportSentinelMockBundleV0:
+		return nil
+	}
+
+	return fmt.Errorf("invalid value for --format: %s\nvalid values: sentinel-mock-bundle-v0", dataType)
+}
+
+// GetPlanCreateOptions return options based on the command's flags value
+func GetPlanCreateOptions(cmd *cobra.Command) PlanCreateOptions {
+	var options PlanCreateOptions
+
+	speculative, err := cmd.Flags().GetBool("speculative")
+	if err != nil {
+		logrus.Fatalf("unable to get flag speculative\n%v", err)
+	}
+
+	options.Speculative = speculative
+
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		logrus.Fatalf("unable to get flag path\n%v", err)
+	}
+
+	options.Path = path
+	options.Wait = GetPlanWaitOptions(cmd)
+
+	return options
+}
+
+// GetPlanWaitOptions return options based on the command's flags value, plan commands always wait
+func GetPlanWaitOptions(cmd *cobra.Command) RunWaitOptions {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		logrus.Fatalf("unable to get flag timeout\n%v", err)
+	}
+
+	pollInterval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		logrus.Fatalf("unable to get flag poll-interval\n%v", err)
+	}
+
+	if pollInterval <= 0 {
+		logrus.Fatalf("--poll-interval must be greater than zero")
+	}
+
+	return RunWaitOptions{Wait: true, Timeout: timeout, PollInterval: pollInterval}
+}
+
+// GetPlanExitCode returns PlanExitChanges if the plan has changes, zero otherwise
+func GetPlanExitCode(plan *tfe.Plan) int {
+	if plan.HasChanges {
+		return PlanExitChanges
+	}
+
+	return 0
+}
+
+// GetPlanMaxDestroy returns the value of --max-destroy, nil when there's no limit
+func GetPlanMaxDestroy(cmd *cobra.Command) *int {
+	return getIntFlagIfChanged(cmd, "max-destroy")
 }
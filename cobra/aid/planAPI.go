@@ -0,0 +1,47 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// PlanAPI calls the plan endpoints the go-tfe client doesn't cover: the JSON execution plan
+type PlanAPI struct {
+	apiClient
+}
+
+// NewPlanAPI returns a client of the plan endpoints given a token and the Terraform Enterprise address
+func NewPlanAPI(token string, address string) *PlanAPI {
+	return &PlanAPI{newAPIClient(token, address)}
+}
+
+// JSONOutput returns the JSON execution plan of a finished plan, as terraform show -json prints it.
+// It's only available to users with admin access to the workspace, for plans made with Terraform 0.12 or later.
+func (a *PlanAPI) JSONOutput(ctx context.Context, planID string) ([]byte, error) {
+	b, err := a.get(ctx, fmt.Sprintf("plans/%s/json-output", url.PathEscape(planID)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("plan %s has no JSON execution plan, it may not be finished yet", planID)
+	}
+
+	return b, nil
+}
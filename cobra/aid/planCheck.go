@@ -0,0 +1,260 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PlanExitRuleViolations is the exit code of plan check when the plan violates rules
+const PlanExitRuleViolations = 9
+
+// Actions rules apply to, using Terraform's vocabulary. A replace is both a delete and a create.
+const (
+	PlanRuleCreate  = "create"
+	PlanRuleUpdate  = "update"
+	PlanRuleDelete  = "delete"
+	PlanRuleReplace = "replace"
+)
+
+// PlanRules is the content of a rules file, checked against the resource changes of plans
+type PlanRules struct {
+	Rules []PlanRule `yaml:"rules"`
+}
+
+// PlanRule matches resource changes by action, resource type and module, then either denies them or requires tags.
+// Empty matchers match everything. Resource types are glob patterns, modules match the module and its children.
+type PlanRule struct {
+	Name          string   `yaml:"name"`
+	Description   string   `yaml:"description"`
+	Actions       []string `yaml:"actions"`
+	ResourceTypes []string `yaml:"resource_types"`
+	Modules       []string `yaml:"modules"`
+	Deny          bool     `yaml:"deny"`
+	RequireTags   []string `yaml:"require_tags"`
+}
+
+// PlanRuleViolation is a resource change violating a rule
+type PlanRuleViolation struct {
+	Rule        string
+	Description string
+	Address     string
+	Action      string
+	Message     string
+}
+
+// ParsePlanRules decodes and validates a rules file
+func ParsePlanRules(b []byte) (*PlanRules, error) {
+	var rules PlanRules
+	if err := yaml.UnmarshalStrict(b, &rules); err != nil {
+		return nil, fmt.Errorf("unable to decode rules file\n%v", err)
+	}
+
+	if len(rules.Rules) == 0 {
+		return nil, fmt.Errorf("rules file doesn't define any rule")
+	}
+
+	names := make(map[string]bool)
+	for i, r := range rules.Rules {
+		if r.Name == "" {
+			return nil, fmt.Errorf("name of rule %d must be defined", i+1)
+		}
+
+		if names[r.Name] {
+			return nil, fmt.Errorf("rule %s is defined more than once", r.Name)
+		}
+		names[r.Name] = true
+
+		if r.Deny == (len(r.RequireTags) > 0) {
+			return nil, fmt.Errorf("rule %s must define either deny or require_tags", r.Name)
+		}
+
+		for _, a := range r.Actions {
+			switch a {
+			case PlanRuleCreate, PlanRuleUpdate, PlanRuleDelete, PlanRuleReplace:
+			default:
+				return nil, fmt.Errorf("invalid action %s in rule %s\nvalid values: create, update, delete or replace", a, r.Name)
+			}
+		}
+
+		for _, t := range r.ResourceTypes {
+			if _, err := path.Match(t, ""); err != nil {
+				return nil, fmt.Errorf("invalid resource type pattern %s in rule %s\n%v", t, r.Name, err)
+			}
+		}
+	}
+
+	return &rules, nil
+}
+
+// CheckPlanRules returns the violations of the rules by the resource changes, in the order of the rules
+func CheckPlanRules(rules *PlanRules, changes []PlanResourceChange) []PlanRuleViolation {
+	var violations []PlanRuleViolation
+
+	for _, r := range rules.Rules {
+		for _, c := range changes {
+			if !planRuleMatches(r, c) {
+				continue
+			}
+
+			violation := PlanRuleViolation{Rule: r.Name, Description: r.Description, Address: c.Address, Action: c.Action}
+
+			if r.Deny {
+				violation.Message = fmt.Sprintf("%s must not be %s", c.Address, getPlanRuleActionVerb(c.Action))
+				violations = append(violations, violation)
+				continue
+			}
+
+			// deleted resources have no tags to check
+			if c.After == nil {
+				continue
+			}
+
+			if missing := getMissingTags(c.After, r.RequireTags); len(missing) > 0 {
+				violation.Message = fmt.Sprintf("%s is missing tags: %s", c.Address, strings.Join(missing, ", "))
+				violations = append(violations, violation)
+			}
+		}
+	}
+
+	return violations
+}
+
+func planRuleMatches(r PlanRule, c PlanResourceChange) bool {
+	if len(r.Actions) > 0 {
+		matched := false
+		for _, a := range r.Actions {
+			if planRuleActionMatches(a, c.Action) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(r.ResourceTypes) > 0 {
+		matched := false
+		for _, t := range r.ResourceTypes {
+			if ok, _ := path.Match(t, c.Type); ok {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(r.Modules) > 0 {
+		matched := false
+		for _, m := range r.Modules {
+			if c.Module == m || strings.HasPrefix(c.Module, m+".") || strings.HasPrefix(c.Module, m+"[") {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// planRuleActionMatches tells if the action of a rule covers the action of a resource change, a replace being a delete and a create
+func planRuleActionMatches(ruleAction string, action string) bool {
+	switch ruleAction {
+	case PlanRuleCreate:
+		return action == PlanActionAdd || action == PlanActionReplace
+	case PlanRuleUpdate:
+		return action == PlanActionChange
+	case PlanRuleDelete:
+		return action == PlanActionDestroy || action == PlanActionReplace
+	case PlanRuleReplace:
+		return action == PlanActionReplace
+	}
+
+	return false
+}
+
+func getPlanRuleActionVerb(action string) string {
+	switch action {
+	case PlanActionAdd:
+		return "created"
+	case PlanActionChange:
+		return "updated"
+	case PlanActionReplace:
+		return "replaced"
+	}
+
+	return "deleted"
+}
+
+// getMissingTags returns the keys missing from both the tags and the tags_all attributes, the latter holding the provider's default tags
+func getMissingTags(after map[string]interface{}, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		found := false
+		for _, attribute := range []string{"tags", "tags_all"} {
+			if tags, ok := after[attribute].(map[string]interface{}); ok {
+				if _, ok := tags[key]; ok {
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+	return missing
+}
+
+// RenderPlanRuleViolations writes a human readable report of the violations into w
+func RenderPlanRuleViolations(w io.Writer, planID string, violations []PlanRuleViolation) error {
+	var sb strings.Builder
+
+	if len(violations) == 0 {
+		fmt.Fprintf(&sb, "plan %s complies with every rule\n", planID)
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	fmt.Fprintf(&sb, "plan %s has %d rule violations:\n", planID, len(violations))
+
+	rule := ""
+	for _, v := range violations {
+		if v.Rule != rule {
+			rule = v.Rule
+			fmt.Fprintf(&sb, "\n%s", v.Rule)
+			if v.Description != "" {
+				fmt.Fprintf(&sb, ": %s", v.Description)
+			}
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "  %s\n", v.Message)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
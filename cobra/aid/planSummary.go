@@ -0,0 +1,417 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Actions of the resources of a plan summary
+const (
+	PlanActionAdd     = "add"
+	PlanActionChange  = "change"
+	PlanActionReplace = "replace"
+	PlanActionDestroy = "destroy"
+)
+
+// Sources a plan summary is extracted from
+const (
+	PlanSummaryFromJSON = "json"
+	PlanSummaryFromLogs = "logs"
+)
+
+// PlanSummary counts the resources a plan adds, changes, replaces and destroys
+type PlanSummary struct {
+	PlanID    string
+	Source    string
+	Add       int
+	Change    int
+	Replace   int
+	Destroy   int
+	Groups    []PlanSummaryGroup
+	Resources []PlanSummaryResource
+}
+
+// PlanSummaryGroup counts the actions on the resources of a type within a module, the root module being empty
+type PlanSummaryGroup struct {
+	Module  string
+	Type    string
+	Add     int
+	Change  int
+	Replace int
+	Destroy int
+}
+
+// PlanSummaryResource is a resource instance the plan acts on
+type PlanSummaryResource struct {
+	Address string
+	Module  string
+	Type    string
+	Action  string
+}
+
+// Destroys returns the number of resources the plan destroys, replaced ones included
+func (s *PlanSummary) Destroys() int {
+	return s.Destroy + s.Replace
+}
+
+// planLogResourceLine matches the header of each resource of a plan log, example: # module.vpc.aws_subnet.a[0] will be created.
+// Headers are indented by two spaces, deeper comments belong to the values of the resources, heredocs for example.
+var planLogResourceLine = regexp.MustCompile(`^  # (.+?)(?: \(deposed object \S+\))? ((?:will|must) be .+|is tainted, so must be replaced)$`)
+
+// planLogBlockLine matches the first line of the block following a resource header, its symbol being the action on the resource
+var planLogBlockLine = regexp.MustCompile(`^ {0,4}(-/\+|\+/-|<=|[-+~])? ?(?:resource|data) "[^"]*" "[^"]*"`)
+
+// planLogCountsLine matches the counts terraform plan ends with, they are checked against the resources found in the logs
+var planLogCountsLine = regexp.MustCompile(`^Plan: (\d+) to add, (\d+) to change, (\d+) to destroy\.`)
+
+// planLogNoChangesLine starts the logs of a plan without changes, which have no counts
+const planLogNoChangesLine = "No changes."
+
+// planLogActionsLine starts the actions of the plan, the resources listed before it changed outside of Terraform
+const planLogActionsLine = "Terraform will perform the following actions:"
+
+// ParsePlanLogs extracts the summary of the plan from its logs, as terraform plan prints them.
+// The symbol of the block following a resource header gives its action, the header only when the block is missing.
+// Headers whose phrasing is unknown are counted as replaced, a destroy is never missed because of the way it's phrased.
+// The summary must match the counts the logs end with, the logs of an unfinished plan, or an unknown format, are an error.
+func ParsePlanLogs(planID string, logs []byte) (*PlanSummary, error) {
+	// colors would get in the way of the regular expressions
+	var clean bytes.Buffer
+	NewLogWriter(&clean, true).Write(logs)
+
+	var resources []PlanSummaryResource
+	var pending *PlanSummaryResource
+	var counts []string
+	noChanges := false
+
+	flush := func() {
+		if pending != nil && pending.Action != "" {
+			resources = append(resources, *pending)
+		}
+		pending = nil
+	}
+
+	for _, line := range strings.Split(clean.String(), "\n") {
+		line = strings.Trim(strings.TrimRight(line, "\r "), "\x02\x03")
+
+		switch {
+		case strings.TrimSpace(line) == planLogActionsLine:
+			pending = nil
+			resources = nil
+			continue
+		case strings.HasPrefix(line, planLogNoChangesLine):
+			noChanges = true
+			continue
+		}
+
+		if m := planLogCountsLine.FindStringSubmatch(line); m != nil {
+			flush()
+			counts = m[1:]
+			continue
+		}
+
+		if m := planLogResourceLine.FindStringSubmatch(line); m != nil {
+			flush()
+			pending = newPlanLogResource(m[1], m[2])
+			continue
+		}
+
+		if m := planLogBlockLine.FindStringSubmatch(line); m != nil && pending != nil {
+			pending.Action = planLogSymbolAction(m[1])
+			flush()
+		}
+	}
+
+	flush()
+
+	summary := newPlanSummary(planID, PlanSummaryFromLogs, resources)
+
+	if counts == nil {
+		if noChanges && len(resources) == 0 {
+			return summary, nil
+		}
+		return nil, fmt.Errorf("unable to find the counts of plan %s in its logs, the plan may not be finished", planID)
+	}
+
+	expected := fmt.Sprintf("%s to add, %s to change, %s to destroy", counts[0], counts[1], counts[2])
+	found := fmt.Sprintf("%d to add, %d to change, %d to destroy", summary.Add+summary.Replace, summary.Change, summary.Destroys())
+	if expected != found {
+		return nil, fmt.Errorf("unable to summarize the logs of plan %s, they list %s, but the plan counts %s", planID, found, expected)
+	}
+
+	return summary, nil
+}
+
+// newPlanLogResource returns the resource of the header, its action being the one the phrase states, replace if it's unknown
+func newPlanLogResource(address string, phrase string) *PlanSummaryResource {
+	var action string
+	switch phrase {
+	case "will be created":
+		action = PlanActionAdd
+	case "will be updated in-place":
+		action = PlanActionChange
+	case "will be destroyed":
+		action = PlanActionDestroy
+	default:
+		action = PlanActionReplace
+	}
+
+	// data sources are read, never changed
+	if strings.HasPrefix(address, "data.") || strings.Contains(address, ".data.") {
+		action = ""
+	}
+
+	module, resourceType := splitResourceAddress(address)
+	return &PlanSummaryResource{Address: address, Module: module, Type: resourceType, Action: action}
+}
+
+// planLogSymbolAction returns the action of the symbol of a block, none for data sources and resources that are only moved
+func planLogSymbolAction(symbol string) string {
+	switch symbol {
+	case "+":
+		return PlanActionAdd
+	case "~":
+		return PlanActionChange
+	case "-":
+		return PlanActionDestroy
+	case "-/+", "+/-":
+		return PlanActionReplace
+	}
+
+	return ""
+}
+
+// PlanResourceChange is a resource instance the JSON execution plan acts on, along with its attributes once applied
+type PlanResourceChange struct {
+	PlanSummaryResource
+	After map[string]interface{}
+}
+
+// ParsePlanResourceChanges returns the managed resources the JSON execution plan adds, changes, replaces or destroys
+func ParsePlanResourceChanges(planID string, b []byte) ([]PlanResourceChange, error) {
+	var plan struct {
+		FormatVersion   string          `json:"format_version"`
+		PlannedValues   json.RawMessage `json:"planned_values"`
+		ResourceChanges []struct {
+			Address       string `json:"address"`
+			ModuleAddress string `json:"module_address"`
+			Mode          string `json:"mode"`
+			Type          string `json:"type"`
+			Change        struct {
+				Actions []string               `json:"actions"`
+				After   map[string]interface{} `json:"after"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+
+	if err := json.Unmarshal(b, &plan); err != nil {
+		return nil, fmt.Errorf("unable to parse the JSON execution plan of plan %s\n%v", planID, err)
+	}
+
+	// any JSON would have no changes, a state file or a file mistaken for the plan must not pass as one
+	if plan.FormatVersion == "" || (plan.ResourceChanges == nil && plan.PlannedValues == nil) {
+		return nil, fmt.Errorf("%s isn't a JSON execution plan, as terraform show -json prints it: format_version and resource_changes or planned_values are missing", planID)
+	}
+
+	var changes []PlanResourceChange
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode == "data" {
+			continue
+		}
+
+		var action string
+		switch strings.Join(rc.Change.Actions, ",") {
+		case "create":
+			action = PlanActionAdd
+		case "update":
+			action = PlanActionChange
+		case "delete":
+			action = PlanActionDestroy
+		case "delete,create", "create,delete":
+			action = PlanActionReplace
+		default:
+			// no-op, read
+			continue
+		}
+
+		changes = append(changes, PlanResourceChange{
+			PlanSummaryResource: PlanSummaryResource{Address: rc.Address, Module: rc.ModuleAddress, Type: rc.Type, Action: action},
+			After:               rc.Change.After,
+		})
+	}
+
+	return changes, nil
+}
+
+// ParsePlanJSON extracts the summary of the plan from its JSON execution plan, as terraform show -json prints it
+func ParsePlanJSON(planID string, b []byte) (*PlanSummary, error) {
+	changes, err := ParsePlanResourceChanges(planID, b)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]PlanSummaryResource, 0, len(changes))
+	for _, c := range changes {
+		resources = append(resources, c.PlanSummaryResource)
+	}
+
+	return newPlanSummary(planID, PlanSummaryFromJSON, resources), nil
+}
+
+func newPlanSummary(planID string, source string, resources []PlanSummaryResource) *PlanSummary {
+	summary := &PlanSummary{PlanID: planID, Source: source, Resources: resources}
+
+	groups := make(map[string]*PlanSummaryGroup)
+	for _, r := range resources {
+		key := r.Module + " " + r.Type
+		g, found := groups[key]
+		if !found {
+			g = &PlanSummaryGroup{Module: r.Module, Type: r.Type}
+			groups[key] = g
+		}
+
+		switch r.Action {
+		case PlanActionAdd:
+			g.Add++
+			summary.Add++
+		case PlanActionChange:
+			g.Change++
+			summary.Change++
+		case PlanActionReplace:
+			g.Replace++
+			summary.Replace++
+		case PlanActionDestroy:
+			g.Destroy++
+			summary.Destroy++
+		}
+	}
+
+	for _, g := range groups {
+		summary.Groups = append(summary.Groups, *g)
+	}
+
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		if summary.Groups[i].Module != summary.Groups[j].Module {
+			return summary.Groups[i].Module < summary.Groups[j].Module
+		}
+		return summary.Groups[i].Type < summary.Groups[j].Type
+	})
+
+	return summary
+}
+
+// splitResourceAddress returns the module address and the type of a resource instance address,
+// example: module.vpc["a.b"].aws_subnet.private[0] returns module.vpc["a.b"] and aws_subnet
+func splitResourceAddress(address string) (string, string) {
+	var segments []string
+	var current strings.Builder
+	depth, quoted := 0, false
+
+	for _, c := range address {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			segments = append(segments, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	segments = append(segments, current.String())
+
+	var module []string
+	i := 0
+	for i+1 < len(segments) && segments[i] == "module" {
+		module = append(module, "module."+segments[i+1])
+		i += 2
+	}
+
+	if i < len(segments) && segments[i] == "data" {
+		i++
+	}
+
+	resourceType := ""
+	if i < len(segments) {
+		resourceType = segments[i]
+	}
+
+	return strings.Join(module, "."), resourceType
+}
+
+// RenderPlanSummary writes a human readable report of the plan summary into w
+func RenderPlanSummary(w io.Writer, summary *PlanSummary) error {
+	var sb strings.Builder
+
+	source := "logs"
+	if summary.Source == PlanSummaryFromJSON {
+		source = "JSON execution plan"
+	}
+	fmt.Fprintf(&sb, "plan %s, summarized from its %s\n", summary.PlanID, source)
+
+	if len(summary.Resources) == 0 {
+		sb.WriteString("\nNo changes.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	sb.WriteString("\n")
+	tw := tabwriter.NewWriter(&sb, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tTYPE\tADD\tCHANGE\tREPLACE\tDESTROY")
+	for _, g := range summary.Groups {
+		module := g.Module
+		if module == "" {
+			module = "(root)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\n", module, g.Type, g.Add, g.Change, g.Replace, g.Destroy)
+	}
+	tw.Flush()
+
+	// what's destroyed deserves to be named
+	var destroyed []string
+	for _, r := range summary.Resources {
+		switch r.Action {
+		case PlanActionReplace:
+			destroyed = append(destroyed, "  -/+ "+r.Address)
+		case PlanActionDestroy:
+			destroyed = append(destroyed, "  -   "+r.Address)
+		}
+	}
+
+	if len(destroyed) > 0 {
+		sb.WriteString("\nDestroyed:\n")
+		sb.WriteString(strings.Join(destroyed, "\n"))
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "\nPlan: %d to add, %d to change, %d to replace, %d to destroy.\n", summary.Add, summary.Change, summary.Replace, summary.Destroy)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetPolicyFlags define flags for the cobra command
+func SetPolicyFlags(cmd *cobra.Command) {
+	usage := `The policy ID. Required for read, update, delete, upload and download.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The name of the policy, which can only include letters, numbers, -, and _.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `A description of the policy's purpose.`
+	cmd.Flags().String("description", "", usage)
+
+	usage = `The enforcement level of the policy. Valid values: advisory, soft-mandatory or hard-mandatory.`
+	cmd.Flags().String("enforcement-level", "", usage)
+
+	usage = `The Sentinel file to upload, or where to write the downloaded policy. Download prints to the standard output by default.`
+	cmd.Flags().String("file", "", usage)
+
+	// List
+	usage = `A search string (partial policy name) used to filter the results.`
+	cmd.Flags().String("search", "", usage)
+
+	SetPaginationFlags(cmd)
+}
+
+// ValidateEnforcementLevel returns an error if the given enforcement level isn't supported
+func ValidateEnforcementLevel(level string) error {
+	switch tfe.EnforcementLevel(level) {
+	case tfe.EnforcementAdvisory, tfe.EnforcementSoft, tfe.EnforcementHard:
+		return nil
+	}
+
+	return fmt.Errorf("invalid value for --enforcement-level: %s\nvalid values: advisory, soft-mandatory or hard-mandatory", level)
+}
+
+// GetPolicyListOptions return options based on the flags values
+func GetPolicyListOptions(cmd *cobra.Command) tfe.PolicyListOptions {
+	var options tfe.PolicyListOptions
+	options.Search = getStringFlagIfChanged(cmd, "search")
+	return options
+}
+
+// GetPolicyCreateOptions return options based on the flags values.
+// The policy is enforced on the file named after it, which is where upload stores its content.
+func GetPolicyCreateOptions(cmd *cobra.Command) tfe.PolicyCreateOptions {
+	var options tfe.PolicyCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag name\n%v", err)
+	}
+
+	options.Name = &name
+	options.Description = getStringFlagIfChanged(cmd, "description")
+	options.Enforce = GetPolicyEnforcementOptions(cmd, name+".sentinel")
+
+	return options
+}
+
+// GetPolicyUpdateOptions return options based on the flags values, path is the enforced path of the existing policy
+func GetPolicyUpdateOptions(cmd *cobra.Command, path string) tfe.PolicyUpdateOptions {
+	var options tfe.PolicyUpdateOptions
+
+	options.Description = getStringFlagIfChanged(cmd, "description")
+	options.Enforce = GetPolicyEnforcementOptions(cmd, path)
+
+	return options
+}
+
+// GetPolicyEnforcementOptions returns the enforcement of the given path, nil if --enforcement-level isn't set
+func GetPolicyEnforcementOptions(cmd *cobra.Command, path string) []*tfe.EnforcementOptions {
+	level := getStringFlagIfChanged(cmd, "enforcement-level")
+	if level == nil {
+		return nil
+	}
+
+	mode := tfe.EnforcementLevel(*level)
+	return []*tfe.EnforcementOptions{{Path: &path, Mode: &mode}}
+}
+
+// PrintPolicyList renders the list items using the given output format
+func PrintPolicyList(list *tfe.PolicyList, format string) {
+	PrintOutput(format, list.Items)
+}
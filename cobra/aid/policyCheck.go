@@ -0,0 +1,81 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// PolicyCheckSummary is the outcome of a policy check, as printed by run read --policy-summary
+type PolicyCheckSummary struct {
+	ID             string
+	Scope          tfe.PolicyScope
+	Status         tfe.PolicyStatus
+	Passed         int
+	AdvisoryFailed int
+	SoftFailed     int
+	HardFailed     int
+	Overridable    bool
+}
+
+// SetPolicyCheckFlags define flags for the cobra command
+func SetPolicyCheckFlags(cmd *cobra.Command) {
+	usage := `The policy check ID. Required for read, logs and override.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The run ID. Required for list.`
+	cmd.Flags().String("run-id", "", usage)
+
+	// Logs
+	SetLogsFlags(cmd)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// GetPolicyCheckSummary returns the outcome of every policy check
+func GetPolicyCheckSummary(checks []*tfe.PolicyCheck) []PolicyCheckSummary {
+	summary := make([]PolicyCheckSummary, 0, len(checks))
+	for _, c := range checks {
+		s := PolicyCheckSummary{ID: c.ID, Scope: c.Scope, Status: c.Status}
+
+		if c.Result != nil {
+			s.Passed = c.Result.Passed
+			s.AdvisoryFailed = c.Result.AdvisoryFailed
+			s.SoftFailed = c.Result.SoftFailed
+			s.HardFailed = c.Result.HardFailed
+		}
+
+		if c.Actions != nil {
+			s.Overridable = c.Actions.IsOverridable
+		}
+
+		summary = append(summary, s)
+	}
+
+	return summary
+}
+
+// IsPolicyCheckOverridable returns true if the policy check soft failed and can be overridden
+func IsPolicyCheckOverridable(check *tfe.PolicyCheck) bool {
+	return check.Status == tfe.PolicySoftFailed && check.Actions != nil && check.Actions.IsOverridable
+}
+
+// PrintPolicyCheckList renders the list items using the given output format
+func PrintPolicyCheckList(list *tfe.PolicyCheckList, format string) {
+	PrintOutput(format, list.Items)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetPolicySetFlags define flags for the cobra command
+func SetPolicySetFlags(cmd *cobra.Command) {
+	usage := `The policy set ID. Required for every argument but list and create.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The name of the policy set, which can only include letters, numbers, -, and _.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `A description of the policy set's purpose.`
+	cmd.Flags().String("description", "", usage)
+
+	usage = `Whether the policy set is enforced on every workspace of the organization.`
+	cmd.Flags().Bool("global", false, usage)
+
+	usage = `The ID of a policy to add or remove, can be repeated.`
+	cmd.Flags().StringArray("policy-id", []string{}, usage)
+
+	usage = `The ID of a workspace to add or remove, can be repeated.`
+	cmd.Flags().StringArray("workspace-id", []string{}, usage)
+
+	usage = `The name of a workspace to add or remove, can be repeated. Names are resolved within --organization.`
+	cmd.Flags().StringArray("workspace", []string{}, usage)
+
+	// List
+	usage = `A search string (partial policy set name) used to filter the results.`
+	cmd.Flags().String("search", "", usage)
+
+	SetPaginationFlags(cmd)
+}
+
+// GetPolicySetListOptions return options based on the flags values
+func GetPolicySetListOptions(cmd *cobra.Command) tfe.PolicySetListOptions {
+	var options tfe.PolicySetListOptions
+	options.Search = getStringFlagIfChanged(cmd, "search")
+	return options
+}
+
+// GetPolicySetCreateOptions return options based on the flags values, the workspaces are set by the caller
+func GetPolicySetCreateOptions(cmd *cobra.Command) tfe.PolicySetCreateOptions {
+	var options tfe.PolicySetCreateOptions
+
+	options.Name = getStringFlagIfChanged(cmd, "name")
+	options.Description = getStringFlagIfChanged(cmd, "description")
+	options.Global = getBoolFlagIfChanged(cmd, "global")
+
+	for _, id := range GetPolicySetPolicyIDs(cmd) {
+		options.Policies = append(options.Policies, &tfe.Policy{ID: id})
+	}
+
+	return options
+}
+
+// GetPolicySetUpdateOptions return options based on the flags values
+func GetPolicySetUpdateOptions(cmd *cobra.Command) tfe.PolicySetUpdateOptions {
+	var options tfe.PolicySetUpdateOptions
+
+	options.Name = getStringFlagIfChanged(cmd, "name")
+	options.Description = getStringFlagIfChanged(cmd, "description")
+	options.Global = getBoolFlagIfChanged(cmd, "global")
+
+	return options
+}
+
+// GetPolicySetPolicyIDs return the policy IDs given by the flags
+func GetPolicySetPolicyIDs(cmd *cobra.Command) []string {
+	ids, err := cmd.Flags().GetStringArray("policy-id")
+	if err != nil {
+		logrus.Fatalf("unable to get flag policy-id\n%v", err)
+	}
+
+	return ids
+}
+
+// GetPolicySetWorkspaces return the workspace IDs and names given by the flags
+func GetPolicySetWorkspaces(cmd *cobra.Command) (ids []string, names []string) {
+	ids, err := cmd.Flags().GetStringArray("workspace-id")
+	if err != nil {
+		logrus.Fatalf("unable to get flag workspace-id\n%v", err)
+	}
+
+	names, err = cmd.Flags().GetStringArray("workspace")
+	if err != nil {
+		logrus.Fatalf("unable to get flag workspace\n%v", err)
+	}
+
+	return ids, names
+}
+
+// PrintPolicySetList renders the list items using the given output format
+func PrintPolicySetList(list *tfe.PolicySetList, format string) {
+	PrintOutput(format, list.Items)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetRegistryModuleFlags define flags for the cobra command
+func SetRegistryModuleFlags(cmd *cobra.Command) {
+	usage := `The name of the module. Required for every argument but list.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `The main provider of the module, example: aws. Required for create without --identifier, create-version, upload and read.`
+	cmd.Flags().String("provider", "", usage)
+
+	usage = `The version of the module, following semantic versioning, example: 1.2.0. Required for create-version and upload.`
+	cmd.Flags().String("version", "", usage)
+
+	usage = `The directory of the module to upload, it must contain .tf files and a README.`
+	cmd.Flags().String("path", "", usage)
+
+	// VCS
+	usage = `The reference to the VCS repository in the format :org/:repo, the repository name must follow the terraform-<PROVIDER>-<NAME> convention.`
+	cmd.Flags().String("identifier", "", usage)
+
+	usage = `The ID of the OAuth token of the VCS connection, see oauth-token list. Required with --identifier.`
+	cmd.Flags().String("oauth-token-id", "", usage)
+
+	usage = `The display identifier of the VCS repository, defaults to --identifier.`
+	cmd.Flags().String("display-identifier", "", usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// GetRegistryModuleCreateOptions return options based on the flags values
+func GetRegistryModuleCreateOptions(cmd *cobra.Command) tfe.RegistryModuleCreateOptions {
+	var options tfe.RegistryModuleCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag name\n%v", err)
+	}
+
+	provider, err := cmd.Flags().GetString("provider")
+	if err != nil {
+		logrus.Fatalf("unable to get flag provider\n%v", err)
+	}
+
+	options.Name = &name
+	options.Provider = &provider
+
+	return options
+}
+
+// GetRegistryModuleCreateWithVCSConnectionOptions return options based on the flags values
+func GetRegistryModuleCreateWithVCSConnectionOptions(cmd *cobra.Command) tfe.RegistryModuleCreateWithVCSConnectionOptions {
+	var options tfe.RegistryModuleCreateWithVCSConnectionOptions
+
+	identifier, err := cmd.Flags().GetString("identifier")
+	if err != nil {
+		logrus.Fatalf("unable to get flag identifier\n%v", err)
+	}
+
+	oauthTokenID, err := cmd.Flags().GetString("oauth-token-id")
+	if err != nil {
+		logrus.Fatalf("unable to get flag oauth-token-id\n%v", err)
+	}
+
+	displayIdentifier, err := cmd.Flags().GetString("display-identifier")
+	if err != nil {
+		logrus.Fatalf("unable to get flag display-identifier\n%v", err)
+	}
+
+	if displayIdentifier == "" {
+		displayIdentifier = identifier
+	}
+
+	options.VCSRepo = &tfe.RegistryModuleVCSRepoOptions{
+		Identifier:        &identifier,
+		OAuthTokenID:      &oauthTokenID,
+		DisplayIdentifier: &displayIdentifier,
+	}
+
+	return options
+}
+
+// ValidateRegistryModuleDir returns an error unless the directory holds a module the registry accepts: .tf files and a README
+func ValidateRegistryModuleDir(path string) error {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("module directory %s not found", path)
+		}
+		return fmt.Errorf("unable to read module directory %s\n%v", path, err)
+	}
+
+	var hasTF, hasReadme bool
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		name := strings.ToLower(f.Name())
+		if filepath.Ext(name) == ".tf" {
+			hasTF = true
+		}
+
+		if name == "readme" || strings.HasPrefix(name, "readme.") {
+			hasReadme = true
+		}
+	}
+
+	if !hasTF {
+		return fmt.Errorf("module directory %s doesn't contain any .tf file", path)
+	}
+
+	if !hasReadme {
+		return fmt.Errorf("module directory %s doesn't contain a README", path)
+	}
+
+	return nil
+}
+
+// PrintRegistryModuleList renders the list items using the given output format
+func PrintRegistryModuleList(list *RegistryModuleList, format string) {
+	PrintOutput(format, list.Items)
+}
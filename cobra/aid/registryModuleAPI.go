@@ -0,0 +1,191 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	slug "github.com/hashicorp/go-slug"
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// RegistryModuleList is a page of the registry modules of an organization
+type RegistryModuleList struct {
+	*tfe.Pagination
+	Items []*tfe.RegistryModule
+}
+
+// RegistryModuleVersion is a version of a registry module along with the link its content is uploaded to
+type RegistryModuleVersion struct {
+	tfe.RegistryModuleVersion
+	UploadURL string
+}
+
+// RegistryModuleAPI calls the registry module endpoints the go-tfe client doesn't cover:
+// listing the modules of an organization, and the upload link of a new module version
+type RegistryModuleAPI struct {
+	apiClient
+}
+
+type registryModuleAttributes struct {
+	Name      string                              `json:"name"`
+	Provider  string                              `json:"provider"`
+	Status    tfe.RegistryModuleStatus            `json:"status"`
+	VCSRepo   *tfe.VCSRepo                        `json:"vcs-repo"`
+	CreatedAt string                              `json:"created-at"`
+	UpdatedAt string                              `json:"updated-at"`
+	Versions  []tfe.RegistryModuleVersionStatuses `json:"version-statuses"`
+}
+
+type registryModuleVersionAttributes struct {
+	Source    string                          `json:"source"`
+	Status    tfe.RegistryModuleVersionStatus `json:"status"`
+	Version   string                          `json:"version"`
+	CreatedAt string                          `json:"created-at"`
+	UpdatedAt string                          `json:"updated-at"`
+}
+
+// NewRegistryModuleAPI returns a client of the registry module endpoints given a token and the Terraform Enterprise address
+func NewRegistryModuleAPI(token string, address string) *RegistryModuleAPI {
+	return &RegistryModuleAPI{newAPIClient(token, address)}
+}
+
+// List a page of the registry modules of the organization
+func (a *RegistryModuleAPI) List(ctx context.Context, organization string, options tfe.ListOptions) (*RegistryModuleList, error) {
+	query := url.Values{}
+	if options.PageNumber > 0 {
+		query.Set("page[number]", strconv.Itoa(options.PageNumber))
+	}
+	if options.PageSize > 0 {
+		query.Set("page[size]", strconv.Itoa(options.PageSize))
+	}
+
+	path := fmt.Sprintf("organizations/%s/registry-modules", url.PathEscape(organization))
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var payload struct {
+		Data []struct {
+			ID         string                   `json:"id"`
+			Attributes registryModuleAttributes `json:"attributes"`
+		} `json:"data"`
+		Meta struct {
+			Pagination struct {
+				CurrentPage  int `json:"current-page"`
+				PreviousPage int `json:"prev-page"`
+				NextPage     int `json:"next-page"`
+				TotalPages   int `json:"total-pages"`
+				TotalCount   int `json:"total-count"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+
+	if err := a.do(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, err
+	}
+
+	p := payload.Meta.Pagination
+	list := &RegistryModuleList{
+		Pagination: &tfe.Pagination{
+			CurrentPage:  p.CurrentPage,
+			PreviousPage: p.PreviousPage,
+			NextPage:     p.NextPage,
+			TotalPages:   p.TotalPages,
+			TotalCount:   p.TotalCount,
+		},
+	}
+
+	for _, d := range payload.Data {
+		list.Items = append(list.Items, &tfe.RegistryModule{
+			ID:              d.ID,
+			Name:            d.Attributes.Name,
+			Provider:        d.Attributes.Provider,
+			Status:          d.Attributes.Status,
+			VCSRepo:         d.Attributes.VCSRepo,
+			VersionStatuses: d.Attributes.Versions,
+			CreatedAt:       d.Attributes.CreatedAt,
+			UpdatedAt:       d.Attributes.UpdatedAt,
+		})
+	}
+
+	return list, nil
+}
+
+// CreateVersion creates a new version of the module, returning the link its content must be uploaded to
+func (a *RegistryModuleAPI) CreateVersion(ctx context.Context, organization string, name string, provider string, version string) (*RegistryModuleVersion, error) {
+	path := fmt.Sprintf("registry-modules/%s/%s/%s/versions", url.PathEscape(organization), url.PathEscape(name), url.PathEscape(provider))
+
+	body := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "registry-module-versions",
+			"attributes": map[string]string{"version": version},
+		},
+	}
+
+	var payload struct {
+		Data struct {
+			ID         string                          `json:"id"`
+			Attributes registryModuleVersionAttributes `json:"attributes"`
+			Links      struct {
+				Upload string `json:"upload"`
+			} `json:"links"`
+		} `json:"data"`
+	}
+
+	if err := a.do(ctx, "POST", path, body, &payload); err != nil {
+		return nil, err
+	}
+
+	d := payload.Data
+	return &RegistryModuleVersion{
+		RegistryModuleVersion: tfe.RegistryModuleVersion{
+			ID:        d.ID,
+			Source:    d.Attributes.Source,
+			Status:    d.Attributes.Status,
+			Version:   d.Attributes.Version,
+			CreatedAt: d.Attributes.CreatedAt,
+			UpdatedAt: d.Attributes.UpdatedAt,
+		},
+		UploadURL: d.Links.Upload,
+	}, nil
+}
+
+// Upload packs the module directory into a tarball and sends it to the upload link of a module version
+func (a *RegistryModuleAPI) Upload(ctx context.Context, uploadURL string, path string) error {
+	if uploadURL == "" {
+		return fmt.Errorf("the module version has no upload link, it may have been uploaded already")
+	}
+
+	body := bytes.NewBuffer(nil)
+	if _, err := slug.Pack(path, body, true); err != nil {
+		return fmt.Errorf("unable to pack module directory %s\n%v", path, err)
+	}
+
+	req, err := http.NewRequest("PUT", uploadURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	_, err = a.send(req.WithContext(ctx))
+	return err
+}
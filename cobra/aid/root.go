@@ -25,6 +25,21 @@ import (
 	"gitlab.aws.dev/devops-aws/tecli/cobra/model"
 )
 
+// ExitError is returned by commands that must exit with a specific status code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 // GetAppInfo return information about tecli settings
 func GetAppInfo() model.App {
 	var err error
@@ -88,9 +103,15 @@ func SetupLoggingLevel(level string) error {
 }
 
 // Returns struct from Terraform Enterprise Cloud API response
-func getTFEConfig(token string) *tfe.Config {
+func getTFEConfig(token string, address string) *tfe.Config {
+	if address == "" {
+		address = tfe.DefaultAddress
+	}
+
 	config := &tfe.Config{
-		Token: token,
+		Address:  address,
+		BasePath: tfe.DefaultBasePath,
+		Token:    token,
 	}
 	return config
 }
@@ -106,9 +127,9 @@ func getTFENewClient(config *tfe.Config) (*tfe.Client, error) {
 	return client, err
 }
 
-// GetTFEClient returns a new terraform api client given a token
-func GetTFEClient(token string) *tfe.Client {
-	config := getTFEConfig(token)
+// GetTFEClient returns a new terraform api client given a token and the Terraform Enterprise address
+func GetTFEClient(token string, address string) *tfe.Client {
+	config := getTFEConfig(token, address)
 	client, err := getTFENewClient(config)
 	if err != nil {
 		logrus.Fatalf("unable to get terraform cloud api client\n%v\n", err)
@@ -16,13 +16,55 @@ limitations under the License.
 package aid
 
 import (
-	"fmt"
+	"context"
+	"time"
 
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// Exit codes used when waiting for a run that didn't finish successfully
+const (
+	RunExitErrored           = 3
+	RunExitCanceled          = 4
+	RunExitDiscarded         = 5
+	RunExitPolicySoftFailed  = 6
+	RunExitTimeout           = 7
+	RunExitNeedsConfirmation = 10
+)
+
+// RunWaitOptions controls how to wait for a run to finish
+type RunWaitOptions struct {
+	Wait         bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+	// Deadline, when set, ends the wait instead of the timeout, so that it covers several steps
+	Deadline time.Time
+}
+
+// WithDeadline returns the options with a single deadline, the timeout from start, shared by every step they're used for
+func (o RunWaitOptions) WithDeadline(start time.Time) RunWaitOptions {
+	if o.Timeout > 0 {
+		o.Deadline = start.Add(o.Timeout)
+	}
+
+	return o
+}
+
+// Context returns the context the wait is bound to, it's done once the deadline or the timeout is reached
+func (o RunWaitOptions) Context() (context.Context, context.CancelFunc) {
+	if !o.Deadline.IsZero() {
+		return context.WithDeadline(context.Background(), o.Deadline)
+	}
+
+	if o.Timeout > 0 {
+		return context.WithTimeout(context.Background(), o.Timeout)
+	}
+
+	return context.WithCancel(context.Background())
+}
+
 // SetRunFlags define flags for the cobra command
 func SetRunFlags(cmd *cobra.Command) {
 	usage := `A list of relations to include. See available resources: https://www.terraform.io/docs/cloud/api/run.html#available-related-resources`
@@ -42,6 +84,7 @@ func SetRunFlags(cmd *cobra.Command) {
 
 	usage = `Specifies the workspace where the run will be executed.`
 	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
 
 	usage = `If non-empty, requests that Terraform should create a plan including actions only for the given objects (specified using resource address syntax) and the objects they depend on. This capability is provided for exceptional circumstances only, such as recovering from mistakes or working around existing Terraform limitations. Terraform will generally mention the -target command line option in its error messages describing situations where setting this argument may be appropriate. This argument should not be used as part of routine workflow and Terraform will emit warnings reminding about this whenever this property is set.`
 	cmd.Flags().StringArray("target-addrs", []string{}, usage)
@@ -49,6 +92,25 @@ func SetRunFlags(cmd *cobra.Command) {
 	usage = `An optional comment about the run.`
 	cmd.Flags().String("comment", "", usage)
 
+	// List
+	SetPaginationFlags(cmd)
+
+	// Create
+	usage = `Wait until the run reaches a final status: applied, planned_and_finished, errored, discarded, canceled or policy_soft_failed, or until it needs confirmation or a policy override. Exit codes: 3 errored, 4 canceled, 5 discarded, 6 policy_soft_failed or policy_override, 7 timeout, 10 needs confirmation.`
+	cmd.Flags().Bool("wait", false, usage)
+
+	usage = `The maximum time to wait for the run to finish, example: 30m. Zero means wait indefinitely.`
+	cmd.Flags().Duration("timeout", 0, usage)
+
+	usage = `How often the run status is checked while waiting.`
+	cmd.Flags().Duration("poll-interval", 5*time.Second, usage)
+
+	usage = `Override the policy checks that soft failed, once the run is policy_override or policy_soft_failed, and keep waiting for the run. Requires --wait.`
+	cmd.Flags().Bool("override-soft-fail", false, usage)
+
+	// Read
+	usage = `Print the outcome of the run's policy checks instead of the run.`
+	cmd.Flags().Bool("policy-summary", false, usage)
 }
 
 // GetRunCreateOptions return options based on the flags values
@@ -163,15 +225,98 @@ func GetRunDiscardOptions(cmd *cobra.Command) tfe.RunDiscardOptions {
 	return options
 }
 
-// PrintRunList TODO ...
-func PrintRunList(list *tfe.RunList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
+// PrintRunList renders the list items using the given output format
+func PrintRunList(list *tfe.RunList, format string) {
+	PrintOutput(format, list.Items)
+}
+
+// GetRunWaitOptions return options based on the command's flags value
+func GetRunWaitOptions(cmd *cobra.Command) RunWaitOptions {
+	var options RunWaitOptions
+
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		logrus.Fatalf("unable to get flag wait\n%v", err)
+	}
+
+	options.Wait = wait
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		logrus.Fatalf("unable to get flag timeout\n%v", err)
+	}
+
+	options.Timeout = timeout
+
+	pollInterval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		logrus.Fatalf("unable to get flag poll-interval\n%v", err)
+	}
+
+	if pollInterval <= 0 {
+		logrus.Fatalf("--poll-interval must be greater than zero")
+	}
+
+	options.PollInterval = pollInterval
+
+	return options
+}
+
+// IsRunFinished returns true if the run reached a final status
+func IsRunFinished(status tfe.RunStatus) bool {
+	switch status {
+	case tfe.RunApplied,
+		tfe.RunPlannedAndFinished,
+		tfe.RunErrored,
+		tfe.RunDiscarded,
+		tfe.RunCanceled,
+		tfe.RunPolicySoftFailed:
+		return true
+	}
+
+	return false
+}
+
+// IsRunConfirmable returns true if the run is waiting for someone to apply it
+func IsRunConfirmable(run *tfe.Run) bool {
+	return run.Actions != nil && run.Actions.IsConfirmable
+}
+
+// IsRunPolicyOverridable returns true if the run stopped on policy checks that soft failed
+func IsRunPolicyOverridable(status tfe.RunStatus) bool {
+	return status == tfe.RunPolicyOverride || status == tfe.RunPolicySoftFailed
+}
+
+// IsRunWaitOver returns true once there's no point in waiting for the run anymore: it finished, or it needs confirmation or a policy override
+func IsRunWaitOver(run *tfe.Run) bool {
+	return IsRunFinished(run.Status) || IsRunConfirmable(run) || run.Status == tfe.RunPolicyOverride
+}
+
+// GetRunWaitExitCode returns the exit code of a run the wait is over for, zero if the run finished successfully
+func GetRunWaitExitCode(run *tfe.Run) int {
+	if run.Status == tfe.RunPolicyOverride {
+		return RunExitPolicySoftFailed
 	}
+
+	if !IsRunFinished(run.Status) && IsRunConfirmable(run) {
+		return RunExitNeedsConfirmation
+	}
+
+	return GetRunExitCode(run.Status)
+}
+
+// GetRunExitCode returns the exit code of a finished run, zero if the run finished successfully
+func GetRunExitCode(status tfe.RunStatus) int {
+	switch status {
+	case tfe.RunErrored:
+		return RunExitErrored
+	case tfe.RunCanceled:
+		return RunExitCanceled
+	case tfe.RunDiscarded:
+		return RunExitDiscarded
+	case tfe.RunPolicySoftFailed:
+		return RunExitPolicySoftFailed
+	}
+
+	return 0
 }
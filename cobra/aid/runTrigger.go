@@ -0,0 +1,387 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// Formats of the run trigger graph
+const (
+	RunTriggerGraphDOT     = "dot"
+	RunTriggerGraphMermaid = "mermaid"
+)
+
+// RunTriggerGraph is the graph of run triggers of an organization, edges go from the source workspace to the triggered one
+type RunTriggerGraph struct {
+	Nodes []RunTriggerNode
+	Edges []RunTriggerEdge
+	// Cycles lists the workspace IDs of each group of workspaces triggering each other
+	Cycles [][]string
+}
+
+// RunTriggerNode is a workspace of the graph.
+// Orphan workspaces are the roots of the graph, sources that trigger other workspaces without being triggered themselves.
+// Missing workspaces aren't part of the organization's workspaces, deleted or not visible with the current token.
+type RunTriggerNode struct {
+	ID      string
+	Name    string
+	Orphan  bool
+	Missing bool
+	InCycle bool
+}
+
+// RunTriggerEdge is a run trigger, from the source workspace to the triggered one
+type RunTriggerEdge struct {
+	ID      string
+	Source  string
+	Target  string
+	InCycle bool
+}
+
+// SetRunTriggerFlags define flags for the cobra command
+func SetRunTriggerFlags(cmd *cobra.Command) {
+	usage := `The run trigger ID. Required for read and delete.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The workspace ID. The workspace runs are triggered on, for create.`
+	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
+
+	usage = `The type of run triggers to list. Valid values: inbound, the workspaces triggering this one, or outbound, the workspaces this one triggers. Both by default.`
+	cmd.Flags().String("type", "", usage)
+
+	usage = `The ID of the source workspace, whose applies trigger runs, for create.`
+	cmd.Flags().String("source-id", "", usage)
+
+	usage = `The name of the source workspace, resolved to its ID within --organization. Can be used instead of --source-id.`
+	cmd.Flags().String("source", "", usage)
+
+	// Graph
+	usage = `The format of the graph. Valid values: dot (Graphviz) or mermaid.`
+	cmd.Flags().String("format", RunTriggerGraphDOT, usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// ValidateRunTriggerType returns an error if the given run trigger type isn't supported
+func ValidateRunTriggerType(t string) error {
+	switch t {
+	case "", "inbound", "outbound":
+		return nil
+	}
+
+	return fmt.Errorf("invalid value for --type: %s\nvalid values: inbound or outbound", t)
+}
+
+// ValidateRunTriggerGraphFormat returns an error if the given graph format isn't supported
+func ValidateRunTriggerGraphFormat(format string) error {
+	switch format {
+	case RunTriggerGraphDOT, RunTriggerGraphMermaid:
+		return nil
+	}
+
+	return fmt.Errorf("invalid value for --format: %s\nvalid values: dot or mermaid", format)
+}
+
+// PrintRunTriggerList renders the list items using the given output format
+func PrintRunTriggerList(list *tfe.RunTriggerList, format string) {
+	PrintOutput(format, list.Items)
+}
+
+// BuildRunTriggerGraph builds the graph of the given run triggers.
+// workspaces maps the ID of every workspace of the organization to its name, only workspaces with run triggers are part of the graph.
+func BuildRunTriggerGraph(workspaces map[string]string, triggers []*tfe.RunTrigger) *RunTriggerGraph {
+	graph := &RunTriggerGraph{}
+
+	nodes := make(map[string]*RunTriggerNode)
+	addNode := func(ws *tfe.Workspace, name string) string {
+		if _, found := nodes[ws.ID]; !found {
+			n := &RunTriggerNode{ID: ws.ID, Name: name}
+			if known, ok := workspaces[ws.ID]; ok {
+				n.Name = known
+			} else {
+				n.Missing = true
+			}
+
+			if n.Name == "" {
+				n.Name = ws.ID
+			}
+
+			nodes[ws.ID] = n
+		}
+
+		return ws.ID
+	}
+
+	adjacency := make(map[string][]string)
+	inbound := make(map[string]int)
+	for _, t := range triggers {
+		if t.Sourceable == nil || t.Workspace == nil {
+			continue
+		}
+
+		source := addNode(t.Sourceable, t.SourceableName)
+		target := addNode(t.Workspace, t.WorkspaceName)
+		graph.Edges = append(graph.Edges, RunTriggerEdge{ID: t.ID, Source: source, Target: target})
+		adjacency[source] = append(adjacency[source], target)
+		inbound[target]++
+	}
+
+	for id, n := range nodes {
+		n.Orphan = inbound[id] == 0
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if nodes[ids[i]].Name == nodes[ids[j]].Name {
+			return ids[i] < ids[j]
+		}
+		return nodes[ids[i]].Name < nodes[ids[j]].Name
+	})
+
+	component := make(map[string]int)
+	for i, scc := range getStronglyConnectedComponents(ids, adjacency) {
+		cyclic := len(scc) > 1
+		if len(scc) == 1 {
+			for _, target := range adjacency[scc[0]] {
+				if target == scc[0] {
+					cyclic = true
+				}
+			}
+		}
+
+		if !cyclic {
+			continue
+		}
+
+		for _, id := range scc {
+			component[id] = i + 1
+			nodes[id].InCycle = true
+		}
+		graph.Cycles = append(graph.Cycles, scc)
+	}
+
+	for i, e := range graph.Edges {
+		graph.Edges[i].InCycle = component[e.Source] != 0 && component[e.Source] == component[e.Target]
+	}
+
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if nodes[a.Source].Name != nodes[b.Source].Name {
+			return nodes[a.Source].Name < nodes[b.Source].Name
+		}
+		return nodes[a.Target].Name < nodes[b.Target].Name
+	})
+
+	for _, id := range ids {
+		graph.Nodes = append(graph.Nodes, *nodes[id])
+	}
+
+	return graph
+}
+
+// getStronglyConnectedComponents implements Tarjan's algorithm, members of each component keep the order of ids
+func getStronglyConnectedComponents(ids []string, adjacency map[string][]string) [][]string {
+	order := make(map[string]int)
+	for i, id := range ids {
+		order[id] = i
+	}
+
+	index := 0
+	indexes := make(map[string]int)
+	lowlinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(id string)
+	connect = func(id string) {
+		indexes[id] = index
+		lowlinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, next := range adjacency[id] {
+			if _, visited := indexes[next]; !visited {
+				connect(next)
+				if lowlinks[next] < lowlinks[id] {
+					lowlinks[id] = lowlinks[next]
+				}
+			} else if onStack[next] && indexes[next] < lowlinks[id] {
+				lowlinks[id] = indexes[next]
+			}
+		}
+
+		if lowlinks[id] != indexes[id] {
+			return
+		}
+
+		var scc []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			scc = append(scc, last)
+			if last == id {
+				break
+			}
+		}
+
+		sort.Slice(scc, func(i, j int) bool { return order[scc[i]] < order[scc[j]] })
+		components = append(components, scc)
+	}
+
+	for _, id := range ids {
+		if _, visited := indexes[id]; !visited {
+			connect(id)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool { return order[components[i][0]] < order[components[j][0]] })
+	return components
+}
+
+// GetRunTriggerGraphWarnings describes the cycles, the orphan sources and the missing workspaces of the graph, one line each
+func GetRunTriggerGraphWarnings(graph *RunTriggerGraph) []string {
+	names := make(map[string]string)
+	for _, n := range graph.Nodes {
+		names[n.ID] = n.Name
+	}
+
+	var warnings []string
+	for _, cycle := range graph.Cycles {
+		members := make([]string, 0, len(cycle))
+		for _, id := range cycle {
+			members = append(members, names[id])
+		}
+		warnings = append(warnings, fmt.Sprintf("cycle: workspaces %s trigger each other", strings.Join(members, ", ")))
+	}
+
+	for _, n := range graph.Nodes {
+		if n.Orphan {
+			warnings = append(warnings, fmt.Sprintf("orphan source: %s (%s) triggers runs, but no workspace triggers it", n.Name, n.ID))
+		}
+	}
+
+	for _, n := range graph.Nodes {
+		if n.Missing {
+			warnings = append(warnings, fmt.Sprintf("missing workspace: %s (%s) isn't a workspace of the organization", n.Name, n.ID))
+		}
+	}
+
+	return warnings
+}
+
+// RenderRunTriggerGraph writes the graph into w using the given format
+func RenderRunTriggerGraph(w io.Writer, graph *RunTriggerGraph, format string) error {
+	var sb strings.Builder
+
+	switch format {
+	case RunTriggerGraphDOT:
+		renderRunTriggerGraphDOT(&sb, graph)
+	case RunTriggerGraphMermaid:
+		renderRunTriggerGraphMermaid(&sb, graph)
+	default:
+		return ValidateRunTriggerGraphFormat(format)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func renderRunTriggerGraphDOT(sb *strings.Builder, graph *RunTriggerGraph) {
+	sb.WriteString("digraph run_triggers {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	for _, n := range graph.Nodes {
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf("label=%q", n.Name))
+		if n.InCycle {
+			attrs = append(attrs, "color=red")
+		}
+		if n.Orphan {
+			attrs = append(attrs, "style=dashed", fmt.Sprintf("tooltip=%q", "orphan source"))
+		}
+		if n.Missing {
+			attrs = append(attrs, "fontcolor=gray", fmt.Sprintf("xlabel=%q", "missing"))
+		}
+		fmt.Fprintf(sb, "  %q [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+
+	for _, e := range graph.Edges {
+		if e.InCycle {
+			fmt.Fprintf(sb, "  %q -> %q [color=red];\n", e.Source, e.Target)
+		} else {
+			fmt.Fprintf(sb, "  %q -> %q;\n", e.Source, e.Target)
+		}
+	}
+
+	sb.WriteString("}\n")
+}
+
+func renderRunTriggerGraphMermaid(sb *strings.Builder, graph *RunTriggerGraph) {
+	sb.WriteString("graph LR\n")
+
+	// mermaid IDs can't hold every character of a workspace ID, nodes are numbered instead
+	ids := make(map[string]string)
+	var cycle, orphan, missing []string
+	for i, n := range graph.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(sb, "  %s[\"%s\"]\n", ids[n.ID], strings.ReplaceAll(n.Name, "\"", "#quot;"))
+
+		if n.InCycle {
+			cycle = append(cycle, ids[n.ID])
+		}
+		if n.Orphan {
+			orphan = append(orphan, ids[n.ID])
+		}
+		if n.Missing {
+			missing = append(missing, ids[n.ID])
+		}
+	}
+
+	for _, e := range graph.Edges {
+		fmt.Fprintf(sb, "  %s --> %s\n", ids[e.Source], ids[e.Target])
+	}
+
+	if len(cycle) > 0 {
+		sb.WriteString("  classDef cycle stroke:#d00,stroke-width:2px\n")
+		fmt.Fprintf(sb, "  class %s cycle\n", strings.Join(cycle, ","))
+	}
+
+	if len(orphan) > 0 {
+		sb.WriteString("  classDef orphan stroke-dasharray:5 5\n")
+		fmt.Fprintf(sb, "  class %s orphan\n", strings.Join(orphan, ","))
+	}
+
+	if len(missing) > 0 {
+		sb.WriteString("  classDef missing color:#999\n")
+		fmt.Fprintf(sb, "  class %s missing\n", strings.Join(missing, ","))
+	}
+}
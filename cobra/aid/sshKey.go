@@ -16,8 +16,6 @@ limitations under the License.
 package aid
 
 import (
-	"fmt"
-
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -84,15 +82,7 @@ func GetSSHKeysUpdateOptions(cmd *cobra.Command) tfe.SSHKeyUpdateOptions {
 
 }
 
-// PrintSSHKeyList TODO ...
-func PrintSSHKeyList(list *tfe.SSHKeyList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
-	}
+// PrintSSHKeyList renders the list items using the given output format
+func PrintSSHKeyList(list *tfe.SSHKeyList, format string) {
+	PrintOutput(format, list.Items)
 }
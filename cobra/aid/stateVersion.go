@@ -0,0 +1,156 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// StateVersionSensitiveValue replaces the value of sensitive outputs, like terraform output does
+const StateVersionSensitiveValue = "<sensitive>"
+
+// TerraformState holds the fields of a Terraform state file tecli needs
+type TerraformState struct {
+	Version          int                             `json:"version"`
+	TerraformVersion string                          `json:"terraform_version"`
+	Serial           int64                           `json:"serial"`
+	Lineage          string                          `json:"lineage"`
+	Outputs          map[string]TerraformStateOutput `json:"outputs"`
+	Resources        []TerraformStateResource        `json:"resources"`
+}
+
+// TerraformStateOutput is a root module output of a Terraform state
+type TerraformStateOutput struct {
+	Value     interface{} `json:"value"`
+	Sensitive bool        `json:"sensitive"`
+}
+
+// TerraformStateResource is a resource of a Terraform state, with one instance per count or for_each key
+type TerraformStateResource struct {
+	Module    string                           `json:"module"`
+	Mode      string                           `json:"mode"`
+	Type      string                           `json:"type"`
+	Name      string                           `json:"name"`
+	Instances []TerraformStateResourceInstance `json:"instances"`
+}
+
+// TerraformStateResourceInstance is an instance of a resource of a Terraform state
+type TerraformStateResourceInstance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// SetStateVersionFlags define flags for the cobra command
+func SetStateVersionFlags(cmd *cobra.Command) {
+	usage := `The state version ID. Required for read. Download and outputs use the current state version of the workspace if not given.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The workspace ID.`
+	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
+
+	usage = `The state file to upload with create, or where download writes the state.`
+	cmd.Flags().String("path", "", usage)
+
+	usage = `Skip the serial and lineage validations of create. Wrong use of this flag can cause data loss.`
+	cmd.Flags().Bool("force", false, usage)
+
+	usage = `Print the value of sensitive outputs instead of masking them.`
+	cmd.Flags().Bool("show-sensitive", false, usage)
+
+	// Diff
+	usage = `The ID of the state version to compare from.`
+	cmd.Flags().String("from", "", usage)
+
+	usage = `The ID of the state version to compare to.`
+	cmd.Flags().String("to", "", usage)
+
+	usage = `Compare the current state version of the workspace with the one this many versions before it.`
+	cmd.Flags().Int("versions-ago", 1, usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// ParseTerraformState decodes a Terraform state file
+func ParseTerraformState(b []byte) (*TerraformState, error) {
+	var state TerraformState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("unable to decode terraform state\n%v", err)
+	}
+
+	if state.Version == 0 || state.Lineage == "" {
+		return nil, fmt.Errorf("not a terraform state file, version and lineage are missing")
+	}
+
+	return &state, nil
+}
+
+// GetStateVersionCreateOptions return options to upload the given state file.
+// The serial and the lineage are read from the state, its MD5 is computed locally.
+func GetStateVersionCreateOptions(cmd *cobra.Command, b []byte) (tfe.StateVersionCreateOptions, error) {
+	var options tfe.StateVersionCreateOptions
+
+	state, err := ParseTerraformState(b)
+	if err != nil {
+		return options, err
+	}
+
+	md5Sum := fmt.Sprintf("%x", md5.Sum(b))
+	encoded := base64.StdEncoding.EncodeToString(b)
+
+	options.Serial = &state.Serial
+	options.Lineage = &state.Lineage
+	options.MD5 = &md5Sum
+	options.State = &encoded
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		logrus.Fatalf("unable to get flag force\n%v", err)
+	}
+
+	if force {
+		options.Force = &force
+	}
+
+	return options, nil
+}
+
+// MaskStateVersionOutputs returns a copy of the outputs with sensitive values masked, unless show is true
+func MaskStateVersionOutputs(outputs []*tfe.StateVersionOutput, show bool) []*tfe.StateVersionOutput {
+	masked := make([]*tfe.StateVersionOutput, 0, len(outputs))
+	for _, o := range outputs {
+		c := *o
+		if c.Sensitive && !show {
+			c.Value = StateVersionSensitiveValue
+		}
+		masked = append(masked, &c)
+	}
+
+	return masked
+}
+
+// PrintStateVersionList renders the list items using the given output format
+func PrintStateVersionList(list *tfe.StateVersionList, format string) {
+	PrintOutput(format, list.Items)
+}
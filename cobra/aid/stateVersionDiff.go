@@ -0,0 +1,264 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Actions of a state diff change
+const (
+	StateDiffAdded   = "added"
+	StateDiffRemoved = "removed"
+	StateDiffChanged = "changed"
+)
+
+// StateDiff lists the resources and outputs that differ between two states
+type StateDiff struct {
+	From       string
+	FromSerial int64
+	To         string
+	ToSerial   int64
+	Resources  []StateDiffChange
+	Outputs    []StateDiffChange
+}
+
+// StateDiffChange is a resource instance or an output that was added, removed or changed.
+// Attributes lists the changed attributes of a resource, Before and After the values of an output.
+type StateDiffChange struct {
+	Address    string
+	Action     string
+	Attributes []string    `json:",omitempty"`
+	Before     interface{} `json:",omitempty"`
+	After      interface{} `json:",omitempty"`
+}
+
+// HasChanges returns true if any resource or output differs
+func (d *StateDiff) HasChanges() bool {
+	return len(d.Resources) > 0 || len(d.Outputs) > 0
+}
+
+// DiffTerraformStates compares two version 4 Terraform states, sensitive output values are masked unless showSensitive is true
+func DiffTerraformStates(from *TerraformState, to *TerraformState, showSensitive bool) (*StateDiff, error) {
+	for _, state := range []*TerraformState{from, to} {
+		if state.Version != 4 {
+			return nil, fmt.Errorf("unsupported terraform state version %d, only version 4 (terraform 0.12 and later) can be compared", state.Version)
+		}
+	}
+
+	diff := &StateDiff{FromSerial: from.Serial, ToSerial: to.Serial}
+
+	before := getTerraformStateInstances(from)
+	after := getTerraformStateInstances(to)
+	for _, address := range sortedKeys(before, after) {
+		b, inBefore := before[address]
+		a, inAfter := after[address]
+
+		switch {
+		case !inBefore:
+			diff.Resources = append(diff.Resources, StateDiffChange{Address: address, Action: StateDiffAdded})
+		case !inAfter:
+			diff.Resources = append(diff.Resources, StateDiffChange{Address: address, Action: StateDiffRemoved})
+		default:
+			if attributes := getChangedAttributes(b, a); len(attributes) > 0 {
+				diff.Resources = append(diff.Resources, StateDiffChange{Address: address, Action: StateDiffChanged, Attributes: attributes})
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(from.Outputs, to.Outputs) {
+		b, inBefore := from.Outputs[name]
+		a, inAfter := to.Outputs[name]
+
+		change := StateDiffChange{Address: name}
+		switch {
+		case !inBefore:
+			change.Action = StateDiffAdded
+		case !inAfter:
+			change.Action = StateDiffRemoved
+		case reflect.DeepEqual(b, a):
+			continue
+		default:
+			change.Action = StateDiffChanged
+		}
+
+		if inBefore {
+			change.Before = getStateOutputValue(b, showSensitive)
+		}
+		if inAfter {
+			change.After = getStateOutputValue(a, showSensitive)
+		}
+
+		diff.Outputs = append(diff.Outputs, change)
+	}
+
+	return diff, nil
+}
+
+// RenderStateDiff writes a human readable report of the diff into w
+func RenderStateDiff(w io.Writer, diff *StateDiff) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "state %s (serial %d) -> %s (serial %d)\n", diff.From, diff.FromSerial, diff.To, diff.ToSerial)
+
+	if !diff.HasChanges() {
+		sb.WriteString("\nNo changes.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	if len(diff.Resources) > 0 {
+		sb.WriteString("\nResources:\n")
+		for _, c := range diff.Resources {
+			fmt.Fprintf(&sb, "  %s %s", getStateDiffSymbol(c.Action), c.Address)
+			if len(c.Attributes) > 0 {
+				fmt.Fprintf(&sb, " (%s)", strings.Join(c.Attributes, ", "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(diff.Outputs) > 0 {
+		sb.WriteString("\nOutputs:\n")
+		for _, c := range diff.Outputs {
+			fmt.Fprintf(&sb, "  %s %s", getStateDiffSymbol(c.Action), c.Address)
+			switch c.Action {
+			case StateDiffAdded:
+				fmt.Fprintf(&sb, " = %s", formatStateValue(c.After))
+			case StateDiffChanged:
+				fmt.Fprintf(&sb, ": %s -> %s", formatStateValue(c.Before), formatStateValue(c.After))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	added, removed, changed := countStateDiffActions(diff.Resources)
+	fmt.Fprintf(&sb, "\nResources: %d added, %d changed, %d removed.\n", added, changed, removed)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// getTerraformStateInstances maps the address of every resource instance to its attributes
+func getTerraformStateInstances(state *TerraformState) map[string]map[string]interface{} {
+	instances := make(map[string]map[string]interface{})
+	for _, r := range state.Resources {
+		address := r.Type + "." + r.Name
+		if r.Mode == "data" {
+			address = "data." + address
+		}
+		if r.Module != "" {
+			address = r.Module + "." + address
+		}
+
+		for _, i := range r.Instances {
+			switch key := i.IndexKey.(type) {
+			case nil:
+				instances[address] = i.Attributes
+			case string:
+				instances[fmt.Sprintf("%s[%q]", address, key)] = i.Attributes
+			default:
+				instances[fmt.Sprintf("%s[%v]", address, key)] = i.Attributes
+			}
+		}
+	}
+
+	return instances
+}
+
+// getChangedAttributes returns the sorted names of the top level attributes that differ
+func getChangedAttributes(before map[string]interface{}, after map[string]interface{}) []string {
+	var changed []string
+	for _, name := range sortedKeys(before, after) {
+		if !reflect.DeepEqual(before[name], after[name]) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+// sortedKeys returns the union of the keys of the given maps, sorted
+func sortedKeys(maps ...interface{}) []string {
+	keys := make(map[string]bool)
+	for _, m := range maps {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			keys[k.String()] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func getStateOutputValue(output TerraformStateOutput, showSensitive bool) interface{} {
+	if output.Sensitive && !showSensitive {
+		return StateVersionSensitiveValue
+	}
+
+	return output.Value
+}
+
+func getStateDiffSymbol(action string) string {
+	switch action {
+	case StateDiffAdded:
+		return "+"
+	case StateDiffRemoved:
+		return "-"
+	}
+
+	return "~"
+}
+
+func formatStateValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		if s == StateVersionSensitiveValue {
+			return s
+		}
+		return fmt.Sprintf("%q", s)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
+
+func countStateDiffActions(changes []StateDiffChange) (added int, removed int, changed int) {
+	for _, c := range changes {
+		switch c.Action {
+		case StateDiffAdded:
+			added++
+		case StateDiffRemoved:
+			removed++
+		default:
+			changed++
+		}
+	}
+
+	return added, removed, changed
+}
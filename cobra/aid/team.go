@@ -0,0 +1,143 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetTeamLookupFlags define the --team flag, used to find a team by name instead of its ID
+func SetTeamLookupFlags(cmd *cobra.Command) {
+	usage := `The team name, resolved to its ID within --organization. Can be used instead of the team ID.`
+	cmd.Flags().String("team", "", usage)
+}
+
+// SetTeamFlags define flags for the cobra command
+func SetTeamFlags(cmd *cobra.Command) {
+	usage := `The team ID. Required for read, update and delete.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The name of the team, which can only include letters, numbers, -, and _.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `A new name for the team.`
+	cmd.Flags().String("new-name", "", usage)
+
+	usage = `The team's visibility. Valid values: secret or organization.`
+	cmd.Flags().String("visibility", "", usage)
+
+	usage = `Allow members to create, edit, and delete the organization's Sentinel policies.`
+	cmd.Flags().Bool("manage-policies", false, usage)
+
+	usage = `Allow members to create and administrate all workspaces within the organization.`
+	cmd.Flags().Bool("manage-workspaces", false, usage)
+
+	usage = `Allow members to manage the organization's VCS Providers and SSH keys.`
+	cmd.Flags().Bool("manage-vcs-settings", false, usage)
+
+	usage = `A list of relations to include. Valid values: users or organization-memberships.`
+	cmd.Flags().String("include", "", usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// GetTeamCreateOptions return options based on the flags values
+func GetTeamCreateOptions(cmd *cobra.Command) tfe.TeamCreateOptions {
+	var options tfe.TeamCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag name\n%v", err)
+	}
+
+	if name != "" {
+		options.Name = &name
+	}
+
+	options.Visibility = getTeamVisibility(cmd)
+	options.OrganizationAccess = getTeamOrganizationAccess(cmd)
+
+	return options
+}
+
+// GetTeamUpdateOptions return options based on the flags values
+func GetTeamUpdateOptions(cmd *cobra.Command) tfe.TeamUpdateOptions {
+	var options tfe.TeamUpdateOptions
+
+	newName, err := cmd.Flags().GetString("new-name")
+	if err != nil {
+		logrus.Fatalf("unable to get flag new-name\n%v", err)
+	}
+
+	if newName != "" {
+		options.Name = &newName
+	}
+
+	options.Visibility = getTeamVisibility(cmd)
+	options.OrganizationAccess = getTeamOrganizationAccess(cmd)
+
+	return options
+}
+
+// GetTeamListOptions return options based on the flags values
+func GetTeamListOptions(cmd *cobra.Command) tfe.TeamListOptions {
+	var options tfe.TeamListOptions
+
+	include, err := cmd.Flags().GetString("include")
+	if err != nil {
+		logrus.Fatalf("unable to get flag include\n%v", err)
+	}
+
+	options.Include = include
+
+	return options
+}
+
+// PrintTeamList renders the list items using the given output format
+func PrintTeamList(list *tfe.TeamList, format string) {
+	PrintOutput(format, list.Items)
+}
+
+func getTeamVisibility(cmd *cobra.Command) *string {
+	visibility, err := cmd.Flags().GetString("visibility")
+	if err != nil {
+		logrus.Fatalf("unable to get flag visibility\n%v", err)
+	}
+
+	if visibility == "" {
+		return nil
+	}
+
+	return &visibility
+}
+
+// getTeamOrganizationAccess returns nil if no organization access flag was set, so the attribute isn't sent
+func getTeamOrganizationAccess(cmd *cobra.Command) *tfe.OrganizationAccessOptions {
+	access := tfe.OrganizationAccessOptions{
+		ManagePolicies:    getBoolFlagIfChanged(cmd, "manage-policies"),
+		ManageWorkspaces:  getBoolFlagIfChanged(cmd, "manage-workspaces"),
+		ManageVCSSettings: getBoolFlagIfChanged(cmd, "manage-vcs-settings"),
+	}
+
+	if access.ManagePolicies == nil && access.ManageWorkspaces == nil && access.ManageVCSSettings == nil {
+		return nil
+	}
+
+	return &access
+}
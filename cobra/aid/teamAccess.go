@@ -0,0 +1,394 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Actions of a team access sync change
+const (
+	TeamAccessSyncAdd    = "add"
+	TeamAccessSyncUpdate = "update"
+	TeamAccessSyncRemove = "remove"
+)
+
+// TeamAccessGrant is the access a team has, or should have, to a workspace.
+// Runs, Variables, StateVersions, SentinelMocks and WorkspaceLocking can only be set when Access is custom.
+type TeamAccessGrant struct {
+	Name             string `yaml:"name"`
+	Access           string `yaml:"access"`
+	Runs             string `yaml:"runs,omitempty"`
+	Variables        string `yaml:"variables,omitempty"`
+	StateVersions    string `yaml:"state-versions,omitempty"`
+	SentinelMocks    string `yaml:"sentinel-mocks,omitempty"`
+	WorkspaceLocking *bool  `yaml:"workspace-locking,omitempty"`
+}
+
+// TeamAccessSyncWorkspace lists the teams that should have access to a workspace
+type TeamAccessSyncWorkspace struct {
+	Name  string            `yaml:"name"`
+	Teams []TeamAccessGrant `yaml:"teams"`
+}
+
+// TeamAccessSyncFile is the access matrix read by team-access sync --file
+type TeamAccessSyncFile struct {
+	Workspaces []TeamAccessSyncWorkspace `yaml:"workspaces"`
+}
+
+// TeamAccessSyncChange is a change required to make the actual grants of a workspace match the access matrix
+type TeamAccessSyncChange struct {
+	Action      string
+	Workspace   string
+	WorkspaceID string
+	Team        string
+	TeamID      string
+	// ID of the existing team access, empty when Action is add
+	ID    string
+	Grant TeamAccessGrant
+}
+
+var teamAccessPermissions = map[string][]string{
+	"access":         {string(tfe.AccessRead), string(tfe.AccessPlan), string(tfe.AccessWrite), string(tfe.AccessAdmin), string(tfe.AccessCustom)},
+	"runs":           {string(tfe.RunsPermissionRead), string(tfe.RunsPermissionPlan), string(tfe.RunsPermissionApply)},
+	"variables":      {string(tfe.VariablesPermissionNone), string(tfe.VariablesPermissionRead), string(tfe.VariablesPermissionWrite)},
+	"state-versions": {string(tfe.StateVersionsPermissionNone), string(tfe.StateVersionsPermissionReadOutputs), string(tfe.StateVersionsPermissionRead), string(tfe.StateVersionsPermissionWrite)},
+	"sentinel-mocks": {string(tfe.SentinelMocksPermissionNone), string(tfe.SentinelMocksPermissionRead)},
+}
+
+// SetTeamAccessFlags define flags for the cobra command
+func SetTeamAccessFlags(cmd *cobra.Command) {
+	usage := `The team access ID. Required for read, update and remove, unless the workspace and the team are given.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The workspace ID.`
+	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
+
+	usage = `The team ID.`
+	cmd.Flags().String("team-id", "", usage)
+	SetTeamLookupFlags(cmd)
+
+	usage = `The type of access to grant. Valid values: read, plan, write, admin or custom.`
+	cmd.Flags().String("access", "", usage)
+
+	usage = `Custom access to runs. Valid values: read, plan or apply.`
+	cmd.Flags().String("runs", "", usage)
+
+	usage = `Custom access to variables. Valid values: none, read or write.`
+	cmd.Flags().String("variables", "", usage)
+
+	usage = `Custom access to state versions. Valid values: none, read-outputs, read or write.`
+	cmd.Flags().String("state-versions", "", usage)
+
+	usage = `Custom access to Sentinel mocks. Valid values: none or read.`
+	cmd.Flags().String("sentinel-mocks", "", usage)
+
+	usage = `Custom access to lock and unlock the workspace.`
+	cmd.Flags().Bool("workspace-locking", false, usage)
+
+	// Sync
+	usage = `The YAML file describing the access every team should have to each workspace.`
+	cmd.Flags().String("file", "", usage)
+
+	usage = `Print the changes sync would make without applying them.`
+	cmd.Flags().Bool("dry-run", false, usage)
+
+	// List
+	SetPaginationFlags(cmd)
+}
+
+// GetTeamAccessGrant return the grant based on the flags values
+func GetTeamAccessGrant(cmd *cobra.Command) TeamAccessGrant {
+	var grant TeamAccessGrant
+
+	for flag, value := range map[string]*string{
+		"access":         &grant.Access,
+		"runs":           &grant.Runs,
+		"variables":      &grant.Variables,
+		"state-versions": &grant.StateVersions,
+		"sentinel-mocks": &grant.SentinelMocks,
+	} {
+		v, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			logrus.Fatalf("unable to get flag %s\n%v", flag, err)
+		}
+		*value = v
+	}
+
+	grant.WorkspaceLocking = getBoolFlagIfChanged(cmd, "workspace-locking")
+
+	return grant
+}
+
+// ValidateTeamAccessGrant returns an error if the grant has invalid values.
+// Access is only required when required is true, custom permissions are only accepted along with custom access.
+func ValidateTeamAccessGrant(grant TeamAccessGrant, required bool) error {
+	if grant.Access == "" && required {
+		return fmt.Errorf("--access must be defined")
+	}
+
+	custom := map[string]string{
+		"runs":           grant.Runs,
+		"variables":      grant.Variables,
+		"state-versions": grant.StateVersions,
+		"sentinel-mocks": grant.SentinelMocks,
+	}
+
+	values := map[string]string{"access": grant.Access}
+	for k, v := range custom {
+		values[k] = v
+	}
+
+	for _, name := range []string{"access", "runs", "variables", "state-versions", "sentinel-mocks"} {
+		if values[name] != "" && !containsString(teamAccessPermissions[name], values[name]) {
+			return fmt.Errorf("invalid value for %s: %s\nvalid values: %v", name, values[name], teamAccessPermissions[name])
+		}
+	}
+
+	if grant.Access != "" && grant.Access != string(tfe.AccessCustom) {
+		for _, name := range []string{"runs", "variables", "state-versions", "sentinel-mocks"} {
+			if custom[name] != "" {
+				return fmt.Errorf("%s can only be set with custom access", name)
+			}
+		}
+
+		if grant.WorkspaceLocking != nil {
+			return fmt.Errorf("workspace-locking can only be set with custom access")
+		}
+	}
+
+	return nil
+}
+
+// GetTeamAccessAddOptions return options based on the grant, the team and the workspace are set by the caller
+func GetTeamAccessAddOptions(grant TeamAccessGrant) tfe.TeamAccessAddOptions {
+	access := tfe.AccessType(grant.Access)
+	options := tfe.TeamAccessAddOptions{Access: &access}
+	options.Runs, options.Variables, options.StateVersions, options.SentinelMocks = getTeamAccessPermissions(grant)
+	options.WorkspaceLocking = grant.WorkspaceLocking
+
+	return options
+}
+
+// GetTeamAccessUpdateOptions return options based on the grant
+func GetTeamAccessUpdateOptions(grant TeamAccessGrant) tfe.TeamAccessUpdateOptions {
+	var options tfe.TeamAccessUpdateOptions
+	if grant.Access != "" {
+		access := tfe.AccessType(grant.Access)
+		options.Access = &access
+	}
+
+	options.Runs, options.Variables, options.StateVersions, options.SentinelMocks = getTeamAccessPermissions(grant)
+	options.WorkspaceLocking = grant.WorkspaceLocking
+
+	return options
+}
+
+func getTeamAccessPermissions(grant TeamAccessGrant) (*tfe.RunsPermissionType, *tfe.VariablesPermissionType, *tfe.StateVersionsPermissionType, *tfe.SentinelMocksPermissionType) {
+	var runs *tfe.RunsPermissionType
+	if grant.Runs != "" {
+		v := tfe.RunsPermissionType(grant.Runs)
+		runs = &v
+	}
+
+	var variables *tfe.VariablesPermissionType
+	if grant.Variables != "" {
+		v := tfe.VariablesPermissionType(grant.Variables)
+		variables = &v
+	}
+
+	var stateVersions *tfe.StateVersionsPermissionType
+	if grant.StateVersions != "" {
+		v := tfe.StateVersionsPermissionType(grant.StateVersions)
+		stateVersions = &v
+	}
+
+	var sentinelMocks *tfe.SentinelMocksPermissionType
+	if grant.SentinelMocks != "" {
+		v := tfe.SentinelMocksPermissionType(grant.SentinelMocks)
+		sentinelMocks = &v
+	}
+
+	return runs, variables, stateVersions, sentinelMocks
+}
+
+// PrintTeamAccessList renders the list items using the given output format
+func PrintTeamAccessList(list *tfe.TeamAccessList, format string) {
+	PrintOutput(format, list.Items)
+}
+
+// ReadTeamAccessSyncFile reads and validates the access matrix
+func ReadTeamAccessSyncFile(path string) (*TeamAccessSyncFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file %s\n%v", path, err)
+	}
+
+	return ParseTeamAccessSyncFile(b)
+}
+
+// ParseTeamAccessSyncFile decodes and validates the access matrix
+func ParseTeamAccessSyncFile(b []byte) (*TeamAccessSyncFile, error) {
+	var file TeamAccessSyncFile
+	if err := yaml.UnmarshalStrict(b, &file); err != nil {
+		return nil, fmt.Errorf("unable to decode team access file\n%v", err)
+	}
+
+	workspaces := make(map[string]bool)
+	for _, w := range file.Workspaces {
+		if w.Name == "" {
+			return nil, fmt.Errorf("workspace name must be defined")
+		}
+
+		if workspaces[w.Name] {
+			return nil, fmt.Errorf("workspace %s is defined more than once", w.Name)
+		}
+		workspaces[w.Name] = true
+
+		teams := make(map[string]bool)
+		for _, t := range w.Teams {
+			if t.Name == "" {
+				return nil, fmt.Errorf("team name must be defined in workspace %s", w.Name)
+			}
+
+			if teams[t.Name] {
+				return nil, fmt.Errorf("team %s is defined more than once in workspace %s", t.Name, w.Name)
+			}
+			teams[t.Name] = true
+
+			if err := ValidateTeamAccessGrant(t, true); err != nil {
+				return nil, fmt.Errorf("invalid access for team %s in workspace %s\n%v", t.Name, w.Name, err)
+			}
+		}
+	}
+
+	return &file, nil
+}
+
+// GetTeamAccessSyncChanges compares the grants a workspace should have with the actual ones.
+// teamIDs maps the team names of the organization to their IDs, teams missing from the matrix lose their access.
+func GetTeamAccessSyncChanges(workspace TeamAccessSyncWorkspace, workspaceID string, teamIDs map[string]string, actual []*tfe.TeamAccess) ([]TeamAccessSyncChange, error) {
+	teamNames := make(map[string]string)
+	for name, id := range teamIDs {
+		teamNames[id] = name
+	}
+
+	current := make(map[string]*tfe.TeamAccess)
+	for _, a := range actual {
+		if a.Team != nil {
+			current[a.Team.ID] = a
+		}
+	}
+
+	var changes []TeamAccessSyncChange
+	desired := make(map[string]bool)
+	for _, grant := range workspace.Teams {
+		teamID, found := teamIDs[grant.Name]
+		if !found {
+			return nil, fmt.Errorf("team %s not found", grant.Name)
+		}
+		desired[teamID] = true
+
+		change := TeamAccessSyncChange{Workspace: workspace.Name, WorkspaceID: workspaceID, Team: grant.Name, TeamID: teamID, Grant: grant}
+		a, found := current[teamID]
+		switch {
+		case !found:
+			change.Action = TeamAccessSyncAdd
+		case !TeamAccessGrantMatches(grant, a):
+			change.Action = TeamAccessSyncUpdate
+			change.ID = a.ID
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	var removed []TeamAccessSyncChange
+	for teamID, a := range current {
+		if desired[teamID] {
+			continue
+		}
+
+		name := teamNames[teamID]
+		if name == "" {
+			name = teamID
+		}
+
+		removed = append(removed, TeamAccessSyncChange{Action: TeamAccessSyncRemove, Workspace: workspace.Name, WorkspaceID: workspaceID, Team: name, TeamID: teamID, ID: a.ID})
+	}
+
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Team < removed[j].Team })
+
+	return append(changes, removed...), nil
+}
+
+// TeamAccessGrantMatches returns true if the actual access already satisfies the grant.
+// Custom permissions missing from the grant are not compared.
+func TeamAccessGrantMatches(grant TeamAccessGrant, access *tfe.TeamAccess) bool {
+	if grant.Access != string(access.Access) {
+		return false
+	}
+
+	if grant.Access != string(tfe.AccessCustom) {
+		return true
+	}
+
+	switch {
+	case grant.Runs != "" && grant.Runs != string(access.Runs):
+		return false
+	case grant.Variables != "" && grant.Variables != string(access.Variables):
+		return false
+	case grant.StateVersions != "" && grant.StateVersions != string(access.StateVersions):
+		return false
+	case grant.SentinelMocks != "" && grant.SentinelMocks != string(access.SentinelMocks):
+		return false
+	case grant.WorkspaceLocking != nil && *grant.WorkspaceLocking != access.WorkspaceLocking:
+		return false
+	}
+
+	return true
+}
+
+// String describes the change, one line per change
+func (c TeamAccessSyncChange) String() string {
+	switch c.Action {
+	case TeamAccessSyncAdd:
+		return fmt.Sprintf("+ grant %s access to team %s on workspace %s", c.Grant.Access, c.Team, c.Workspace)
+	case TeamAccessSyncUpdate:
+		return fmt.Sprintf("~ update access of team %s on workspace %s to %s", c.Team, c.Workspace, c.Grant.Access)
+	}
+
+	return fmt.Sprintf("- remove access of team %s on workspace %s", c.Team, c.Workspace)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// SetTeamMemberFlags define flags for the cobra command
+func SetTeamMemberFlags(cmd *cobra.Command) {
+	usage := `The team ID.`
+	cmd.Flags().String("team-id", "", usage)
+
+	usage = `The username of a user to add or remove, can be repeated.`
+	cmd.Flags().StringArray("username", []string{}, usage)
+
+	usage = `The ID of a user to add or remove, can be repeated. Requires --organization to find the user's membership.`
+	cmd.Flags().StringArray("user-id", []string{}, usage)
+}
+
+// GetTeamMemberUsers return the usernames and user IDs given by the flags
+func GetTeamMemberUsers(cmd *cobra.Command) (usernames []string, userIDs []string) {
+	usernames, err := cmd.Flags().GetStringArray("username")
+	if err != nil {
+		logrus.Fatalf("unable to get flag username\n%v", err)
+	}
+
+	userIDs, err = cmd.Flags().GetStringArray("user-id")
+	if err != nil {
+		logrus.Fatalf("unable to get flag user-id\n%v", err)
+	}
+
+	return usernames, userIDs
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// terraformRCCredentials matches credentials blocks of the Terraform CLI configuration, example:
+//
+//	credentials "app.terraform.io" {
+//	  token = "xxxxxx.atlasv1.zzzzzzzzzzzzz"
+//	}
+var terraformRCCredentials = regexp.MustCompile(`(?s)credentials\s+"([^"]+)"\s*\{[^}]*?token\s*=\s*"([^"]*)"[^}]*\}`)
+
+// terraformCredentialsJSON is the format of the file written by terraform login
+type terraformCredentialsJSON struct {
+	Credentials map[string]struct {
+		Token string `json:"token"`
+	} `json:"credentials"`
+}
+
+// GetHostname returns the hostname of the given address, or Terraform Cloud's hostname if empty
+func GetHostname(address string) string {
+	if address == "" {
+		address = tfe.DefaultAddress
+	}
+
+	u, err := url.Parse(address)
+	if err != nil || u.Host == "" {
+		// address given without scheme, example: tfe.example.com
+		return strings.TrimSuffix(address, "/")
+	}
+
+	return u.Host
+}
+
+// GetTerraformCredentialsFiles returns the paths of the Terraform CLI credentials file and configuration file
+func GetTerraformCredentialsFiles() (credentialsPath string, configPath string) {
+	home := getHomeDir()
+
+	if runtime.GOOS == "windows" {
+		appData := os.Getenv("APPDATA")
+		credentialsPath = filepath.Join(appData, "terraform.d", "credentials.tfrc.json")
+		configPath = filepath.Join(appData, "terraform.rc")
+	} else {
+		credentialsPath = filepath.Join(home, ".terraform.d", "credentials.tfrc.json")
+		configPath = filepath.Join(home, ".terraformrc")
+	}
+
+	if path := os.Getenv("TF_CLI_CONFIG_FILE"); path != "" {
+		configPath = path
+	}
+
+	return credentialsPath, configPath
+}
+
+// ParseTerraformCredentialsJSON returns the tokens per hostname of a credentials.tfrc.json file
+func ParseTerraformCredentialsJSON(b []byte) (map[string]string, error) {
+	var creds terraformCredentialsJSON
+	if err := json.Unmarshal(b, &creds); err != nil {
+		return nil, fmt.Errorf("unable to decode terraform credentials\n%v", err)
+	}
+
+	tokens := make(map[string]string)
+	for hostname, c := range creds.Credentials {
+		tokens[hostname] = c.Token
+	}
+
+	return tokens, nil
+}
+
+// ParseTerraformRC returns the tokens per hostname of the credentials blocks of a Terraform CLI configuration file
+func ParseTerraformRC(b []byte) map[string]string {
+	tokens := make(map[string]string)
+	for _, m := range terraformRCCredentials.FindAllSubmatch(b, -1) {
+		tokens[string(m[1])] = string(m[2])
+	}
+
+	return tokens
+}
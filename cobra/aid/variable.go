@@ -1,8 +1,6 @@
 package aid
 
 import (
-	"fmt"
-
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -15,6 +13,7 @@ func SetVariableFlags(cmd *cobra.Command) {
 
 	usage = "The workspace ID."
 	cmd.Flags().String("workspace-id", "", usage)
+	SetWorkspaceLookupFlags(cmd)
 
 	usage = "The name of the variable."
 	cmd.Flags().String("key", "", usage)
@@ -34,6 +33,9 @@ func SetVariableFlags(cmd *cobra.Command) {
 	usage = "Whether the value is sensitive."
 	cmd.Flags().Bool("sensitive", false, usage)
 
+	// List
+	SetPaginationFlags(cmd)
+
 }
 
 // GetVariableCreateOptions return tfe.VariableCreateOptions with correpondent values given by the flags
@@ -176,15 +178,7 @@ func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
 	return options
 }
 
-// PrintVariableList convert struct to JSON and displays to user
-func PrintVariableList(list *tfe.VariableList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
-	}
+// PrintVariableList renders the list items using the given output format
+func PrintVariableList(list *tfe.VariableList, format string) {
+	PrintOutput(format, list.Items)
 }
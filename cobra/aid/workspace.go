@@ -1,13 +1,17 @@
 package aid
 
 import (
-	"fmt"
-
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// SetWorkspaceLookupFlags define the flag to refer to a workspace by name instead of ID
+func SetWorkspaceLookupFlags(cmd *cobra.Command) {
+	usage := `The workspace name, resolved to its ID within --organization. Can be used instead of the workspace ID.`
+	cmd.Flags().String("workspace", "", usage)
+}
+
 // SetWorkspaceFlags define flags for the cobra command
 func SetWorkspaceFlags(cmd *cobra.Command) {
 
@@ -18,11 +22,16 @@ func SetWorkspaceFlags(cmd *cobra.Command) {
 	usage = `A list of relations to include. See available resources https://www.terraform.io/docs/cloud/api/workspaces.html#available-related-resources`
 	cmd.Flags().String("include", "", usage)
 
+	SetPaginationFlags(cmd)
+
 	// Create, Update
 
 	usage = `The workspace ID`
 	cmd.Flags().String("id", "", usage)
 
+	// Lock, Unlock, Force Unlock, Assign SSH Key, Unassign SSH Key
+	SetWorkspaceLookupFlags(cmd)
+
 	usage = `Required when execution-mode is set to agent. The ID of the agent pool belonging to the workspace's organization. This value must not be specified if execution-mode is set to remote or local or if operations is set to true.`
 	cmd.Flags().String("agent-pool-id", "", usage)
 
@@ -420,15 +429,7 @@ func GetWorkspaceAssignSSHKeyOptions(cmd *cobra.Command) tfe.WorkspaceAssignSSHK
 	return options
 }
 
-// PrintWorkspaceList TODO ...
-func PrintWorkspaceList(list *tfe.WorkspaceList) {
-	if len(list.Items) > 0 {
-		for i, item := range list.Items {
-			if i < len(list.Items)-1 {
-				fmt.Printf("%v,\n", ToJSON(item))
-			} else {
-				fmt.Printf("%v\n", ToJSON(item))
-			}
-		}
-	}
+// PrintWorkspaceList renders the list items using the given output format
+func PrintWorkspaceList(list *tfe.WorkspaceList, format string) {
+	PrintOutput(format, list.Items)
 }
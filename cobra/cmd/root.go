@@ -16,6 +16,7 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -40,6 +41,12 @@ var logFilePath string
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
+
+		var exitErr *aid.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+
 		os.Exit(1)
 	}
 }
@@ -61,6 +68,8 @@ func initConfig() {
 	viper.BindEnv("USER_TOKEN")
 	viper.BindEnv("TEAM_TOKEN")
 	viper.BindEnv("ORGANIZATION_TOKEN")
+	viper.BindEnv("ADDRESS")
+	viper.BindEnv("CREDENTIALS_PASSPHRASE")
 
 	app := aid.GetAppInfo()
 
@@ -82,19 +91,20 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
+	// stdout is kept for the output of the commands, --output json for example is parsed by scripts
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "using config file:", viper.ConfigFileUsed())
 	}
 
 	// if config is not found, that's okay, as the user might use env vars
 
 	if log == "enable" && logFilePath != "" {
 		if err := aid.SetupLoggingLevel(verbosity); err == nil {
-			fmt.Printf("logging level: %s\n", verbosity)
+			fmt.Fprintf(os.Stderr, "logging level: %s\n", verbosity)
 		}
 
 		if err := aid.SetupLoggingOutput(logFilePath); err == nil {
-			fmt.Printf("logging path: %s\n", logFilePath)
+			fmt.Fprintf(os.Stderr, "logging path: %s\n", logFilePath)
 		}
 	}
 
@@ -0,0 +1,227 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var agentPoolValidArgs = []string{"list", "create", "read", "update", "delete"}
+
+// AgentPoolCmd command to manage the agent pools of an organization
+func AgentPoolCmd() *cobra.Command {
+	man, err := helper.GetManual("agent-pool", agentPoolValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: agentPoolValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   agentPoolPreRun,
+		RunE:      agentPoolRun,
+	}
+
+	aid.SetAgentPoolFlags(cmd)
+
+	return cmd
+}
+
+func agentPoolPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "agent-pool"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-pool", fArg, "organization"); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-pool", fArg, "organization"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-pool", fArg, "name"); err != nil {
+			return err
+		}
+
+	case "read", "delete":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-pool", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "update":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-pool", fArg, "id"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-pool", fArg, "name"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func agentPoolRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		list, err := agentPoolList(client, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintAgentPoolList(list, output)
+		} else {
+			return fmt.Errorf("no agent pool was found\n%v", err)
+		}
+
+	case "create":
+		pool, err := agentPoolCreate(client, aid.GetAgentPoolCreateOptions(cmd))
+		if err == nil && pool.ID != "" {
+			aid.PrintOutput(output, pool)
+		} else {
+			return fmt.Errorf("unable to create agent pool\n%v", err)
+		}
+
+	case "read":
+		pool, err := agentPoolRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, pool)
+		} else {
+			return fmt.Errorf("agent pool %s not found\n%v", id, err)
+		}
+
+	case "update":
+		pool, err := agentPoolUpdate(client, id, aid.GetAgentPoolUpdateOptions(cmd))
+		if err == nil && pool.ID != "" {
+			aid.PrintOutput(output, pool)
+		} else {
+			return fmt.Errorf("unable to update agent pool\n%v", err)
+		}
+
+	case "delete":
+		err := agentPoolDelete(client, id)
+		if err == nil {
+			fmt.Printf("agent pool %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete agent pool %s\n%v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// validateAgentPoolLookup checks the agent pool is given either by ID, using idFlag, or by name, using --agent-pool
+func validateAgentPoolLookup(cmd *cobra.Command, idFlag string) error {
+	id := helper.GetCmdFlagString(cmd, idFlag)
+	name := helper.GetCmdFlagString(cmd, "agent-pool")
+
+	if id == "" && name == "" {
+		return fmt.Errorf("either --%s or --agent-pool must be defined", idFlag)
+	}
+
+	if id != "" && name != "" {
+		return fmt.Errorf("--%s and --agent-pool are mutually exclusive", idFlag)
+	}
+
+	if name != "" && organization == "" {
+		return fmt.Errorf("--organization must be defined when using --agent-pool")
+	}
+
+	return nil
+}
+
+// agentPoolLookup returns the agent pool ID given by idFlag, or resolves the --agent-pool name to its ID
+func agentPoolLookup(client *tfe.Client, cmd *cobra.Command, idFlag string) (string, error) {
+	if id := helper.GetCmdFlagString(cmd, idFlag); id != "" {
+		return id, nil
+	}
+
+	name := helper.GetCmdFlagString(cmd, "agent-pool")
+	list, err := agentPoolList(client, aid.PaginationOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to list agent pools of organization %s\n%v", organization, err)
+	}
+
+	for _, pool := range list.Items {
+		if pool.Name == name {
+			return pool.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to find agent pool %s in organization %s", name, organization)
+}
+
+// List all the agent pools of the organization.
+func agentPoolList(client *tfe.Client, pagination aid.PaginationOptions) (*tfe.AgentPoolList, error) {
+	list := &tfe.AgentPoolList{}
+	options := tfe.AgentPoolListOptions{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.AgentPools.List(context.Background(), organization, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create an agent pool with the given options.
+func agentPoolCreate(client *tfe.Client, options tfe.AgentPoolCreateOptions) (*tfe.AgentPool, error) {
+	return client.AgentPools.Create(context.Background(), organization, options)
+}
+
+// Read an agent pool by its ID.
+func agentPoolRead(client *tfe.Client, agentPoolID string) (*tfe.AgentPool, error) {
+	return client.AgentPools.Read(context.Background(), agentPoolID)
+}
+
+// Update an existing agent pool.
+func agentPoolUpdate(client *tfe.Client, agentPoolID string, options tfe.AgentPoolUpdateOptions) (*tfe.AgentPool, error) {
+	return client.AgentPools.Update(context.Background(), agentPoolID, options)
+}
+
+// Delete an agent pool by its ID.
+func agentPoolDelete(client *tfe.Client, agentPoolID string) error {
+	return client.AgentPools.Delete(context.Background(), agentPoolID)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var agentTokenValidArgs = []string{"list", "generate", "read", "delete"}
+
+// AgentTokenCmd command to manage the tokens agents use to join an agent pool
+func AgentTokenCmd() *cobra.Command {
+	man, err := helper.GetManual("agent-token", agentTokenValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: agentTokenValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   agentTokenPreRun,
+		RunE:      agentTokenRun,
+	}
+
+	aid.SetAgentTokenFlags(cmd)
+
+	return cmd
+}
+
+func agentTokenPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "agent-token"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list", "generate":
+		if err := validateAgentPoolLookup(cmd, "agent-pool-id"); err != nil {
+			return err
+		}
+
+	case "read", "delete":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "agent-token", fArg, "id"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func agentTokenRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		agentPoolID, err := agentPoolLookup(client, cmd, "agent-pool-id")
+		if err != nil {
+			return err
+		}
+
+		list, err := agentTokenList(client, agentPoolID)
+		if err == nil {
+			aid.PrintAgentTokenList(list, output)
+		} else {
+			return fmt.Errorf("no agent token was found\n%v", err)
+		}
+
+	case "generate":
+		agentPoolID, err := agentPoolLookup(client, cmd, "agent-pool-id")
+		if err != nil {
+			return err
+		}
+
+		// the token can't be read again, so the file is opened before it's generated
+		path := helper.GetCmdFlagString(cmd, "token-file")
+		var tokenFile *os.File
+		created := false
+		if path != "" {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				created = true
+			}
+
+			tokenFile, err = aid.OpenSecretFile(path)
+			if err != nil {
+				return fmt.Errorf("unable to open %s for the agent token\n%v", path, err)
+			}
+		}
+
+		at, err := agentTokenGenerate(client, agentPoolID, aid.GetAgentTokenGenerateOptions(cmd))
+		if err != nil || at.ID == "" {
+			if tokenFile != nil {
+				tokenFile.Close()
+				if created {
+					os.Remove(path)
+				}
+			}
+			return fmt.Errorf("unable to generate agent token\n%v", err)
+		}
+
+		// it's either written to the file or printed, never both
+		if path == "" {
+			fmt.Fprintf(os.Stderr, "agent token %s generated, store its token now, it can't be retrieved again\n", at.ID)
+			aid.PrintOutput(output, at)
+			return nil
+		}
+
+		if err := aid.WriteSecret(tokenFile, []byte(at.Token)); err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("agent token %s was generated but couldn't be written to %s, delete it and try again\n%v", at.ID, path, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "agent token %s written to %s\n", at.ID, path)
+		at.Token = ""
+		aid.PrintOutput(output, at)
+
+	case "read":
+		at, err := agentTokenRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, at)
+		} else {
+			return fmt.Errorf("agent token %s not found\n%v", id, err)
+		}
+
+	case "delete":
+		err := agentTokenDelete(client, id)
+		if err == nil {
+			fmt.Printf("agent token %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete agent token %s\n%v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// List all the agent tokens of the agent pool.
+func agentTokenList(client *tfe.Client, agentPoolID string) (*tfe.AgentTokenList, error) {
+	return client.AgentTokens.List(context.Background(), agentPoolID)
+}
+
+// Generate a new agent token for the agent pool, its secret is only returned once.
+func agentTokenGenerate(client *tfe.Client, agentPoolID string, options tfe.AgentTokenGenerateOptions) (*tfe.AgentToken, error) {
+	return client.AgentTokens.Generate(context.Background(), agentPoolID, options)
+}
+
+// Read an agent token by its ID.
+func agentTokenRead(client *tfe.Client, agentTokenID string) (*tfe.AgentToken, error) {
+	return client.AgentTokens.Read(context.Background(), agentTokenID)
+}
+
+// Delete an agent token by its ID.
+func agentTokenDelete(client *tfe.Client, agentTokenID string) error {
+	return client.AgentTokens.Delete(context.Background(), agentTokenID)
+}
@@ -75,7 +75,7 @@ func applyPreRun(cmd *cobra.Command, args []string) error {
 func applyRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
@@ -87,7 +87,7 @@ func applyRun(cmd *cobra.Command, args []string) error {
 
 		apply, err := applyRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(apply))
+			aid.PrintOutput(output, apply)
 		} else {
 			return fmt.Errorf("apply %s not found\n%v", id, err)
 		}
@@ -101,7 +101,10 @@ func applyRun(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			logrus.Fatalf("unable to read apply logs\n%v", err)
 		}
-		fmt.Println(StreamToString(logs))
+
+		if err := printLogs(logs, aid.GetLogsOptions(cmd)); err != nil {
+			return fmt.Errorf("unable to print apply logs\n%v", err)
+		}
 	}
 
 	return nil
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/spf13/cobra"
@@ -61,7 +62,7 @@ func configurationVersionPreRun(cmd *cobra.Command, args []string) error {
 	fArg := args[0]
 	switch fArg {
 	case "list", "create":
-		if err := helper.ValidateCmdArgAndFlag(cmd, args, "configuration-version", fArg, "workspace-id"); err != nil {
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
 			return err
 		}
 
@@ -85,34 +86,34 @@ func configurationVersionPreRun(cmd *cobra.Command, args []string) error {
 func configurationVersionRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
-		list, err := configurationVersionList(client, workspaceID, tfe.ConfigurationVersionListOptions{})
+		list, err := configurationVersionList(client, workspaceID, tfe.ConfigurationVersionListOptions{}, aid.GetPaginationOptions(cmd))
 		if err == nil {
-			aid.PrintConfigurationVersionList(list)
+			aid.PrintConfigurationVersionList(list, output)
 		} else {
 			return fmt.Errorf("no configurationVersion was found")
 		}
 
 	case "create":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
 		options := aid.GetConfigurationVersionCreateOptions(cmd)
 		cv, err := configurationVersionCreate(client, workspaceID, options)
 
 		if err == nil && cv.ID != "" {
-			fmt.Println(aid.ToJSON(cv))
+			aid.PrintOutput(output, cv)
 		} else {
 			return fmt.Errorf("unable to create configuration version\n%v", err)
 		}
@@ -124,7 +125,7 @@ func configurationVersionRun(cmd *cobra.Command, args []string) error {
 
 		cv, err := configurationVersionRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(cv))
+			aid.PrintOutput(output, cv)
 		} else {
 			return fmt.Errorf("configuration version %s not found\n%v", id, err)
 		}
@@ -152,8 +153,22 @@ func configurationVersionRun(cmd *cobra.Command, args []string) error {
 }
 
 // List returns all configuration versions of a workspace.
-func configurationVersionList(client *tfe.Client, workspaceID string, options tfe.ConfigurationVersionListOptions) (*tfe.ConfigurationVersionList, error) {
-	return client.ConfigurationVersions.List(context.Background(), workspaceID, options)
+func configurationVersionList(client *tfe.Client, workspaceID string, options tfe.ConfigurationVersionListOptions, pagination aid.PaginationOptions) (*tfe.ConfigurationVersionList, error) {
+	list := &tfe.ConfigurationVersionList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.ConfigurationVersions.List(context.Background(), workspaceID, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 // Create is used to create a new configuration version. The created
@@ -173,3 +188,32 @@ func configurationVersionRead(client *tfe.Client, cvID string) (*tfe.Configurati
 func configurationVersionUpload(client *tfe.Client, url string, path string) error {
 	return client.ConfigurationVersions.Upload(context.Background(), url, path)
 }
+
+// WaitUploaded polls the configuration version until its files are processed.
+func configurationVersionWaitUploaded(client *tfe.Client, cvID string, options aid.RunWaitOptions) (*tfe.ConfigurationVersion, error) {
+	ctx, cancel := options.Context()
+	defer cancel()
+
+	for {
+		cv, err := client.ConfigurationVersions.Read(ctx, cvID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for configuration version %s", options.Timeout, cvID)}
+			}
+			return nil, fmt.Errorf("unable to read configuration version %s\n%v", cvID, err)
+		}
+
+		switch cv.Status {
+		case tfe.ConfigurationUploaded:
+			return cv, nil
+		case tfe.ConfigurationErrored:
+			return nil, fmt.Errorf("configuration version %s errored\n%s", cvID, cv.ErrorMessage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for configuration version %s", options.Timeout, cvID)}
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -29,7 +29,7 @@ import (
 	"gitlab.aws.dev/devops-aws/tecli/helper"
 )
 
-var configureValidArgs = []string{"list", "create", "read", "update", "delete"}
+var configureValidArgs = []string{"list", "create", "read", "update", "delete", "encrypt", "decrypt", "import"}
 
 // ConfigureCmd command to display tecli current version
 func ConfigureCmd() *cobra.Command {
@@ -62,11 +62,22 @@ func configurePreRun(cmd *cobra.Command, args []string) error {
 
 	fArg := args[0]
 	switch fArg {
-	case "list", "create", "read", "update", "delete":
+	case "list", "create", "read", "update", "delete", "encrypt", "decrypt", "import":
 		if err := helper.ValidateCmdArgAndFlag(cmd, args, "configure", fArg, "profile"); err != nil {
 			return err
 		}
 
+		if fArg == "import" {
+			from, err := cmd.Flags().GetString("from")
+			if err != nil {
+				return fmt.Errorf("unable to get flag from\n%v", err)
+			}
+
+			if from != "terraform" {
+				return fmt.Errorf("--from must be defined, valid values: terraform")
+			}
+		}
+
 	default:
 		return fmt.Errorf("unknown argument provided")
 	}
@@ -86,7 +97,7 @@ func configureRun(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			logrus.Fatalf("unable to list credentials\n%v", err)
 		}
-		fmt.Println(aid.ToJSON(creds))
+		aid.PrintOutput(output, creds.Profiles)
 
 	case "create":
 		err = configureCreateCredentials(cmd, mode)
@@ -100,7 +111,7 @@ func configureRun(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("unable to read credential")
 		}
-		fmt.Println(aid.ToJSON(c))
+		aid.PrintOutput(output, c)
 
 	case "update":
 		err = configureUpdateCredentials(cmd, mode)
@@ -116,6 +127,33 @@ func configureRun(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("profile %s delete successfully\n", profile)
 
+	case "import":
+		err := configureImportCredentials(cmd)
+		if err != nil {
+			return fmt.Errorf("unable to import profile\n%v", err)
+		}
+		fmt.Printf("profile %s imported successfully\n", profile)
+
+	case "encrypt", "decrypt":
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return fmt.Errorf("unable to get flag all\n%v", err)
+		}
+
+		backend := aid.CredentialBackendEncrypted
+		if fArg == "decrypt" {
+			backend = aid.CredentialBackendPlain
+		}
+
+		names, err := configureSetCredentialsBackend(backend, all)
+		if err != nil {
+			return fmt.Errorf("unable to %s credentials\n%v", fArg, err)
+		}
+
+		for _, name := range names {
+			fmt.Printf("profile %s %sed successfully\n", name, fArg)
+		}
+
 	default:
 		return fmt.Errorf("unknown argument provided")
 	}
@@ -196,6 +234,13 @@ func configureUpdateCredentials(cmd *cobra.Command, mode string) error {
 		for i, p := range creds.Profiles {
 			if p.Name == profile {
 				found = true
+
+				// the tokens that aren't updated must be kept when the profile is encrypted again
+				p, err = dao.DecryptCredentialProfile(p)
+				if err != nil {
+					return err
+				}
+
 				if mode == "interactive" {
 					creds.Profiles[i] = view.AskAboutCredentialProfile(cmd, p)
 				} else if mode == "non-interactive" {
@@ -238,3 +283,100 @@ func configureDeleteCredential() error {
 
 	return nil
 }
+
+// configureSetCredentialsBackend migrates the current profile, or all profiles, to the given backend
+func configureSetCredentialsBackend(backend string, all bool) ([]string, error) {
+	creds, err := dao.GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i, p := range creds.Profiles {
+		if !all && p.Name != profile {
+			continue
+		}
+
+		if aid.IsEncryptedCredentialProfile(p) == (backend == aid.CredentialBackendEncrypted) {
+			if !all {
+				return nil, fmt.Errorf("profile %s is already using the %s backend", p.Name, backend)
+			}
+			continue
+		}
+
+		if backend == aid.CredentialBackendEncrypted {
+			// make sure the user knows the passphrase before encrypting anything with it
+			if _, err := aid.GetNewCredentialsPassphrase(p.Name); err != nil {
+				return nil, err
+			}
+		} else {
+			p, err = dao.DecryptCredentialProfile(p)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		p.Backend = backend
+		creds.Profiles[i] = p
+		names = append(names, p.Name)
+	}
+
+	if len(names) == 0 {
+		if !all {
+			return nil, fmt.Errorf("profile %s not found", profile)
+		}
+		return nil, nil
+	}
+
+	return names, dao.SaveCredentials(creds)
+}
+
+// configureImportCredentials creates the profile using the token stored by the Terraform CLI
+func configureImportCredentials(cmd *cobra.Command) error {
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return fmt.Errorf("unable to get flag address\n%v", err)
+	}
+
+	hostname := aid.GetHostname(address)
+	token, err := dao.GetTerraformCLIToken(hostname)
+	if err != nil {
+		return err
+	}
+
+	created, err := aid.HasCreatedConfigDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	var creds model.Credentials
+	if !created {
+		creds, err = dao.GetCredentials()
+		if err != nil {
+			return err
+		}
+
+		for _, p := range creds.Profiles {
+			if p.Name == profile {
+				// don't add duplicates
+				return fmt.Errorf("profile %s already exist\nprofile names must be unique", profile)
+			}
+		}
+	}
+
+	now := time.Now().String()
+	cp := model.CredentialProfile{
+		Name:        profile,
+		Description: "imported from terraform credentials of " + hostname,
+		Enabled:     true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		// terraform login creates user tokens, they can be used wherever a team token is expected
+		UserToken: token,
+		TeamToken: token,
+		Address:   address,
+	}
+
+	creds.Profiles = append(creds.Profiles, cp)
+	return dao.SaveCredentials(creds)
+}
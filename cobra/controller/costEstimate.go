@@ -0,0 +1,214 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var costEstimateValidArgs = []string{"read", "logs", "report"}
+
+// CostEstimateCmd command to read the cost estimates of runs, and report the monthly cost of an organization
+func CostEstimateCmd() *cobra.Command {
+	man, err := helper.GetManual("cost-estimate", costEstimateValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: costEstimateValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   costEstimatePreRun,
+		RunE:      costEstimateRun,
+	}
+
+	aid.SetCostEstimateFlags(cmd)
+
+	return cmd
+}
+
+func costEstimatePreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "cost-estimate"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "read", "logs":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "cost-estimate", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "report":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "cost-estimate", fArg, "organization"); err != nil {
+			return err
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%v", err)
+		}
+
+		if err := aid.ValidateCostEstimateReportFormat(format); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func costEstimateRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "read":
+		ce, err := costEstimateRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, ce)
+		} else {
+			return fmt.Errorf("cost estimate %s not found\n%v", id, err)
+		}
+
+	case "logs":
+		logs, err := costEstimateLogs(client, id)
+		if err != nil {
+			return fmt.Errorf("unable to read cost estimate logs\n%v", err)
+		}
+
+		if err := printLogs(logs, aid.GetLogsOptions(cmd)); err != nil {
+			return fmt.Errorf("unable to print cost estimate logs\n%v", err)
+		}
+
+	case "report":
+		report, failed, err := costEstimateReport(client)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("output") {
+			aid.PrintOutput(output, report)
+		} else {
+			format, _ := cmd.Flags().GetString("format")
+			if err := aid.RenderCostEstimateReport(os.Stdout, report, format); err != nil {
+				return err
+			}
+		}
+
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("unable to read the cost estimate of %d of %d workspaces", failed, len(report.Workspaces))
+		}
+	}
+
+	return nil
+}
+
+// costEstimateReport gathers the cost estimate of the current run of every workspace of the organization.
+// Workspaces without runs, or whose current run has no cost estimate, are left out of the report.
+// Workspaces whose cost estimate can't be read are kept with the error status, and counted as failed.
+func costEstimateReport(client *tfe.Client) (*aid.CostEstimateReport, int, error) {
+	workspaces, err := workspaceList(client, tfe.WorkspaceListOptions{}, aid.PaginationOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to list workspaces of organization %s\n%v", organization, err)
+	}
+
+	var rows []aid.CostEstimateReportRow
+	var skipped, failed int
+	for _, w := range workspaces.Items {
+		if w.CurrentRun == nil || w.CurrentRun.ID == "" {
+			skipped++
+			continue
+		}
+
+		errored := aid.CostEstimateReportRow{Workspace: w.Name, WorkspaceID: w.ID, RunID: w.CurrentRun.ID, Status: aid.CostEstimateReportErrored}
+
+		ce, err := costEstimateOfRun(client, w.CurrentRun.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to read the cost estimate of run %s of workspace %s\n%v\n", w.CurrentRun.ID, w.Name, err)
+			failed++
+			rows = append(rows, errored)
+			continue
+		}
+
+		if ce == nil {
+			skipped++
+			continue
+		}
+
+		row, err := aid.NewCostEstimateReportRow(w, w.CurrentRun.ID, ce)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to read the cost estimate of workspace %s\n%v\n", w.Name, err)
+			failed++
+			errored.CostEstimateID = ce.ID
+			rows = append(rows, errored)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d workspaces have no cost estimate for their current run\n", skipped, len(workspaces.Items))
+	}
+
+	return aid.NewCostEstimateReport(organization, rows), failed, nil
+}
+
+// costEstimateOfRun returns the cost estimate of the run, nil if cost estimation didn't happen for the run
+func costEstimateOfRun(client *tfe.Client, runID string) (*tfe.CostEstimate, error) {
+	run, err := client.Runs.ReadWithOptions(context.Background(), runID, &tfe.RunReadOptions{Include: "cost_estimate"})
+	if err != nil {
+		return nil, err
+	}
+
+	if run.CostEstimate == nil || run.CostEstimate.ID == "" {
+		return nil, nil
+	}
+
+	if run.CostEstimate.Status != "" {
+		return run.CostEstimate, nil
+	}
+
+	return costEstimateRead(client, run.CostEstimate.ID)
+}
+
+// Read a cost estimate by its ID.
+func costEstimateRead(client *tfe.Client, costEstimateID string) (*tfe.CostEstimate, error) {
+	return client.CostEstimates.Read(context.Background(), costEstimateID)
+}
+
+// Logs retrieves the logs of a cost estimate.
+func costEstimateLogs(client *tfe.Client, costEstimateID string) (io.Reader, error) {
+	return client.CostEstimates.Logs(context.Background(), costEstimateID)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+// DeployCmd command to upload a local Terraform directory and queue a run
+func DeployCmd() *cobra.Command {
+	man, err := helper.GetManual("deploy", []string{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:     man.Use,
+		Short:   man.Short,
+		Long:    man.Long,
+		Example: man.Example,
+		Args:    cobra.NoArgs,
+		PreRunE: deployPreRun,
+		RunE:    deployRun,
+	}
+
+	aid.SetDeployFlags(cmd)
+
+	return cmd
+}
+
+func deployPreRun(cmd *cobra.Command, args []string) error {
+	if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+		return err
+	}
+
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return fmt.Errorf("unable to get flag path\n%v", err)
+	}
+
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return fmt.Errorf("--path must be an existing directory: %s", path)
+	}
+
+	return nil
+}
+
+func deployRun(cmd *cobra.Command, args []string) error {
+	// from now on, errors are about the deploy itself, not how the command was used
+	cmd.SilenceUsage = true
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	options := aid.GetDeployOptions(cmd)
+
+	workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+	if err != nil {
+		return err
+	}
+
+	workspace, err := workspaceReadByID(client, workspaceID)
+	if err != nil {
+		return fmt.Errorf("unable to find workspace %s\n%v", workspaceID, err)
+	}
+
+	run, err := deploy(client, workspace, options)
+	if err != nil {
+		return err
+	}
+
+	aid.PrintOutput(output, run)
+	if options.Wait.Wait {
+		return runWaitExitError(run)
+	}
+
+	return nil
+}
+
+// deploy uploads the configuration files to the workspace and queues a run using them
+func deploy(client *tfe.Client, workspace *tfe.Workspace, options aid.DeployOptions) (*tfe.Run, error) {
+	cv, err := configurationVersionCreate(client, workspace.ID, tfe.ConfigurationVersionCreateOptions{AutoQueueRuns: tfe.Bool(false)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create configuration version\n%v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "configuration version %s created on workspace %s\n", cv.ID, workspace.Name)
+
+	if err := configurationVersionUpload(client, cv.UploadURL, options.Path); err != nil {
+		return nil, fmt.Errorf("unable to upload %s to configuration version %s\n%v", options.Path, cv.ID, err)
+	}
+
+	cv, err = configurationVersionWaitUploaded(client, cv.ID, options.Wait)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(os.Stderr, "configuration version %s uploaded\n", cv.ID)
+
+	runOptions := tfe.RunCreateOptions{
+		Workspace:            workspace,
+		ConfigurationVersion: cv,
+		IsDestroy:            tfe.Bool(options.IsDestroy),
+	}
+
+	if options.Message != "" {
+		runOptions.Message = tfe.String(options.Message)
+	}
+
+	run, err := runCreate(client, runOptions)
+	if err != nil || run.ID == "" {
+		return nil, fmt.Errorf("unable to create run\n%v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "run %s created\n", run.ID)
+
+	if !options.Wait.Wait {
+		return run, nil
+	}
+
+	// stops once the run needs confirmation too, so it can be applied
+	run, err = runWait(client, run.ID, options.Wait)
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.AutoApply || aid.GetRunWaitExitCode(run) != aid.RunExitNeedsConfirmation {
+		return run, nil
+	}
+
+	applyOptions := tfe.RunApplyOptions{}
+	if options.Comment != "" {
+		applyOptions.Comment = tfe.String(options.Comment)
+	}
+
+	if err := runApply(client, run.ID, applyOptions); err != nil {
+		return nil, fmt.Errorf("unable to apply run %s\n%v", run.ID, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "run %s applied\n", run.ID)
+
+	return runWait(client, run.ID, options.Wait)
+}
@@ -0,0 +1,341 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var notificationValidArgs = []string{"list", "create", "read", "update", "delete", "verify"}
+
+// NotificationCmd command to manage the notification configurations of workspaces
+func NotificationCmd() *cobra.Command {
+	man, err := helper.GetManual("notification", notificationValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: notificationValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   notificationPreRun,
+		RunE:      notificationRun,
+	}
+
+	aid.SetNotificationFlags(cmd)
+
+	return cmd
+}
+
+func notificationPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "notification"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "name"); err != nil {
+			return err
+		}
+
+		if err := aid.ValidateNotificationFlags(cmd, true); err != nil {
+			return err
+		}
+
+		if err := validateNotificationWorkspaces(cmd); err != nil {
+			return err
+		}
+
+	case "read", "update", "delete", "verify":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "id"); err != nil {
+			return err
+		}
+
+		if fArg == "update" {
+			if err := aid.ValidateNotificationFlags(cmd, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func notificationRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		list, err := notificationList(client, workspaceID, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintNotificationList(list, output)
+		} else {
+			return fmt.Errorf("no notification configuration was found\n%v", err)
+		}
+
+	case "create":
+		options := aid.GetNotificationCreateOptions(cmd)
+
+		if !isNotificationBulk(cmd) {
+			workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+			if err != nil {
+				return err
+			}
+
+			nc, err := notificationCreate(client, workspaceID, options)
+			if err == nil && nc.ID != "" {
+				aid.PrintOutput(output, nc)
+			} else {
+				return fmt.Errorf("unable to create notification configuration\n%v", err)
+			}
+
+			return nil
+		}
+
+		workspaces, err := notificationWorkspaces(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		return notificationCreateBulk(client, workspaces, options)
+
+	case "read":
+		nc, err := notificationRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, nc)
+		} else {
+			return fmt.Errorf("notification configuration %s not found\n%v", id, err)
+		}
+
+	case "update":
+		nc, err := notificationUpdate(client, id, aid.GetNotificationUpdateOptions(cmd))
+		if err == nil && nc.ID != "" {
+			aid.PrintOutput(output, nc)
+		} else {
+			return fmt.Errorf("unable to update notification configuration\n%v", err)
+		}
+
+	case "delete":
+		err := notificationDelete(client, id)
+		if err == nil {
+			fmt.Printf("notification configuration %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete notification configuration %s\n%v", id, err)
+		}
+
+	case "verify":
+		nc, err := notificationVerify(client, id)
+		if err == nil {
+			aid.PrintOutput(output, nc)
+		} else {
+			return fmt.Errorf("unable to verify notification configuration %s\n%v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func isNotificationBulk(cmd *cobra.Command) bool {
+	return helper.GetCmdFlagString(cmd, "search") != "" || helper.GetCmdFlagString(cmd, "workspaces-file") != ""
+}
+
+// validateNotificationWorkspaces returns an error unless a single workspace, --search or --workspaces-file is given
+func validateNotificationWorkspaces(cmd *cobra.Command) error {
+	if !isNotificationBulk(cmd) {
+		return validateWorkspaceLookup(cmd, "workspace-id")
+	}
+
+	if helper.GetCmdFlagString(cmd, "search") != "" && helper.GetCmdFlagString(cmd, "workspaces-file") != "" {
+		return fmt.Errorf("--search and --workspaces-file are mutually exclusive")
+	}
+
+	if helper.GetCmdFlagString(cmd, "workspace-id") != "" || helper.GetCmdFlagString(cmd, "workspace") != "" {
+		return fmt.Errorf("--search and --workspaces-file can't be used along with --workspace-id or --workspace")
+	}
+
+	if organization == "" {
+		return fmt.Errorf("--organization must be defined when using --search or --workspaces-file")
+	}
+
+	return nil
+}
+
+// notificationWorkspaces returns the workspaces matching --search, or listed in --workspaces-file.
+// Every workspace of the file must exist, so nothing is created if one of them is misspelled.
+func notificationWorkspaces(client *tfe.Client, cmd *cobra.Command) ([]*tfe.Workspace, error) {
+	if search := helper.GetCmdFlagString(cmd, "search"); search != "" {
+		list, err := workspaceList(client, tfe.WorkspaceListOptions{Search: &search}, aid.PaginationOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to search workspaces of organization %s\n%v", organization, err)
+		}
+
+		if len(list.Items) == 0 {
+			return nil, fmt.Errorf("no workspace of organization %s matches %s", organization, search)
+		}
+
+		return list.Items, nil
+	}
+
+	names, err := aid.ReadWorkspaceNamesFile(helper.GetCmdFlagString(cmd, "workspaces-file"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no workspace was found in %s", helper.GetCmdFlagString(cmd, "workspaces-file"))
+	}
+
+	var workspaces []*tfe.Workspace
+	for _, name := range names {
+		workspace, err := workspaceRead(client, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find workspace %s in organization %s\n%v", name, organization, err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces, nil
+}
+
+// notificationCreateBulk creates the notification configuration on every workspace.
+// Workspaces that already have a notification configuration with the same name are skipped, so it's safe to run again.
+func notificationCreateBulk(client *tfe.Client, workspaces []*tfe.Workspace, options tfe.NotificationConfigurationCreateOptions) error {
+	var created []*tfe.NotificationConfiguration
+	var failed int
+
+	for _, w := range workspaces {
+		existing, err := notificationFindByName(client, w.ID, *options.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to list notification configurations of workspace %s\n%v\n", w.Name, err)
+			failed++
+			continue
+		}
+
+		if existing != nil {
+			fmt.Fprintf(os.Stderr, "workspace %s already has notification configuration %s (%s), skipping\n", w.Name, existing.Name, existing.ID)
+			continue
+		}
+
+		nc, err := notificationCreate(client, w.ID, options)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to create notification configuration on workspace %s\n%v\n", w.Name, err)
+			failed++
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "notification configuration %s (%s) created on workspace %s\n", nc.Name, nc.ID, w.Name)
+		created = append(created, nc)
+	}
+
+	aid.PrintOutput(output, created)
+
+	if failed > 0 {
+		return fmt.Errorf("unable to create the notification configuration on %d of %d workspaces", failed, len(workspaces))
+	}
+
+	return nil
+}
+
+// notificationFindByName returns the notification configuration of the workspace with the given name, nil if there's none
+func notificationFindByName(client *tfe.Client, workspaceID string, name string) (*tfe.NotificationConfiguration, error) {
+	list, err := notificationList(client, workspaceID, aid.PaginationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nc := range list.Items {
+		if nc.Name == name {
+			return nc, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// List all the notification configurations of the workspace.
+func notificationList(client *tfe.Client, workspaceID string, pagination aid.PaginationOptions) (*tfe.NotificationConfigurationList, error) {
+	list := &tfe.NotificationConfigurationList{}
+	options := tfe.NotificationConfigurationListOptions{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.NotificationConfigurations.List(context.Background(), workspaceID, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a notification configuration with the given options.
+func notificationCreate(client *tfe.Client, workspaceID string, options tfe.NotificationConfigurationCreateOptions) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Create(context.Background(), workspaceID, options)
+}
+
+// Read a notification configuration by its ID.
+func notificationRead(client *tfe.Client, notificationConfigurationID string) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Read(context.Background(), notificationConfigurationID)
+}
+
+// Update an existing notification configuration.
+func notificationUpdate(client *tfe.Client, notificationConfigurationID string, options tfe.NotificationConfigurationUpdateOptions) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Update(context.Background(), notificationConfigurationID, options)
+}
+
+// Delete a notification configuration by its ID.
+func notificationDelete(client *tfe.Client, notificationConfigurationID string) error {
+	return client.NotificationConfigurations.Delete(context.Background(), notificationConfigurationID)
+}
+
+// Verify a notification configuration by delivering a verification payload to the configured URL.
+func notificationVerify(client *tfe.Client, notificationConfigurationID string) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Verify(context.Background(), notificationConfigurationID)
+}
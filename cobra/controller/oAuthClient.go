@@ -101,14 +101,14 @@ func oAuthClientPreRun(cmd *cobra.Command, args []string) error {
 func oAuthClientRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
-		list, err := oAuthClientList(client)
+		list, err := oAuthClientList(client, aid.GetPaginationOptions(cmd))
 		if err == nil {
-			aid.PrintOAuthClientList(list)
+			aid.PrintOAuthClientList(list, output)
 		} else {
 			return fmt.Errorf("no o-auth-clients was found")
 		}
@@ -118,7 +118,7 @@ func oAuthClientRun(cmd *cobra.Command, args []string) error {
 		oAuthClient, err := oAuthClientCreate(client, options)
 
 		if err == nil && oAuthClient.ID != "" {
-			fmt.Println(aid.ToJSON(oAuthClient))
+			aid.PrintOutput(output, oAuthClient)
 		} else {
 			return fmt.Errorf("unable to create o-auth-client\n%v", err)
 		}
@@ -130,7 +130,7 @@ func oAuthClientRun(cmd *cobra.Command, args []string) error {
 
 		oAuthClient, err := oAuthClientRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(oAuthClient))
+			aid.PrintOutput(output, oAuthClient)
 		} else {
 			return fmt.Errorf("o-auth-client %s not found\n%v", id, err)
 		}
@@ -151,8 +151,21 @@ func oAuthClientRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func oAuthClientList(client *tfe.Client) (*tfe.OAuthClientList, error) {
-	return client.OAuthClients.List(context.Background(), organization, tfe.OAuthClientListOptions{})
+func oAuthClientList(client *tfe.Client, pagination aid.PaginationOptions) (*tfe.OAuthClientList, error) {
+	list := &tfe.OAuthClientList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		page, err := client.OAuthClients.List(context.Background(), organization, tfe.OAuthClientListOptions{ListOptions: listOptions})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 // Create is used to create a new oAuthClient.
@@ -81,14 +81,14 @@ func oAuthTokenPreRun(cmd *cobra.Command, args []string) error {
 func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
-		list, err := oAuthTokenList(client)
+		list, err := oAuthTokenList(client, aid.GetPaginationOptions(cmd))
 		if err == nil {
-			aid.PrintOAuthTokenList(list)
+			aid.PrintOAuthTokenList(list, output)
 		} else {
 			return fmt.Errorf("no o-auth-tokens was found")
 		}
@@ -100,7 +100,7 @@ func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 
 		oAuthToken, err := oAuthTokenRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(oAuthToken))
+			aid.PrintOutput(output, oAuthToken)
 		} else {
 			return fmt.Errorf("o-auth-token %s not found\n%v", id, err)
 		}
@@ -114,7 +114,7 @@ func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 		oAuthToken, err := oAuthTokenUpdate(client, id, options)
 
 		if err == nil && oAuthToken.ID != "" {
-			fmt.Println(aid.ToJSON(oAuthToken))
+			aid.PrintOutput(output, oAuthToken)
 		} else {
 			return fmt.Errorf("unable to create o-auth-token\n%v", err)
 		}
@@ -135,8 +135,21 @@ func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func oAuthTokenList(client *tfe.Client) (*tfe.OAuthTokenList, error) {
-	return client.OAuthTokens.List(context.Background(), organization, tfe.OAuthTokenListOptions{})
+func oAuthTokenList(client *tfe.Client, pagination aid.PaginationOptions) (*tfe.OAuthTokenList, error) {
+	list := &tfe.OAuthTokenList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		page, err := client.OAuthTokens.List(context.Background(), organization, tfe.OAuthTokenListOptions{ListOptions: listOptions})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 // Read an OAuth client by its ID.
@@ -0,0 +1,196 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var organizationValidArgs = []string{"list", "create", "read", "update", "delete", "capacity", "entitlements"}
+
+// OrganizationCmd command to manage organizations
+func OrganizationCmd() *cobra.Command {
+	man, err := helper.GetManual("organization", organizationValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: organizationValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   organizationPreRun,
+		RunE:      organizationRun,
+	}
+
+	aid.SetOrganizationFlags(cmd)
+
+	return cmd
+}
+
+func organizationPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "organization"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "organization", fArg, "name"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "organization", fArg, "email"); err != nil {
+			return err
+		}
+
+	case "read", "update", "delete", "capacity", "entitlements":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "organization", fArg, "organization"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func organizationRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		list, err := organizationList(client, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintOrganizationList(list, output)
+		} else {
+			return fmt.Errorf("no organization was found\n%v", err)
+		}
+
+	case "create":
+		options := aid.GetOrganizationCreateOptions(cmd)
+		org, err := organizationCreate(client, options)
+		if err == nil && org.Name != "" {
+			aid.PrintOutput(output, org)
+		} else {
+			return fmt.Errorf("unable to create organization\n%v", err)
+		}
+
+	case "read":
+		org, err := organizationRead(client, organization)
+		if err == nil {
+			aid.PrintOutput(output, org)
+		} else {
+			return fmt.Errorf("organization %s not found\n%v", organization, err)
+		}
+
+	case "update":
+		options := aid.GetOrganizationUpdateOptions(cmd)
+		org, err := organizationUpdate(client, organization, options)
+		if err == nil && org.Name != "" {
+			aid.PrintOutput(output, org)
+		} else {
+			return fmt.Errorf("unable to update organization\n%v", err)
+		}
+
+	case "delete":
+		err := organizationDelete(client, organization)
+		if err == nil {
+			fmt.Printf("organization %s deleted successfully\n", organization)
+		} else {
+			return fmt.Errorf("unable to delete organization %s\n%v", organization, err)
+		}
+
+	case "capacity":
+		capacity, err := organizationCapacity(client, organization)
+		if err == nil {
+			aid.PrintOutput(output, capacity)
+		} else {
+			return fmt.Errorf("unable to read capacity of organization %s\n%v", organization, err)
+		}
+
+	case "entitlements":
+		entitlements, err := organizationEntitlements(client, organization)
+		if err == nil {
+			aid.PrintOutput(output, entitlements)
+		} else {
+			return fmt.Errorf("unable to read entitlements of organization %s\n%v", organization, err)
+		}
+	}
+
+	return nil
+}
+
+// List all the organizations visible to the current user.
+func organizationList(client *tfe.Client, pagination aid.PaginationOptions) (*tfe.OrganizationList, error) {
+	list := &tfe.OrganizationList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		page, err := client.Organizations.List(context.Background(), tfe.OrganizationListOptions{ListOptions: listOptions})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a new organization with the given options.
+func organizationCreate(client *tfe.Client, options tfe.OrganizationCreateOptions) (*tfe.Organization, error) {
+	return client.Organizations.Create(context.Background(), options)
+}
+
+// Read an organization by its name.
+func organizationRead(client *tfe.Client, name string) (*tfe.Organization, error) {
+	return client.Organizations.Read(context.Background(), name)
+}
+
+// Update attributes of an existing organization.
+func organizationUpdate(client *tfe.Client, name string, options tfe.OrganizationUpdateOptions) (*tfe.Organization, error) {
+	return client.Organizations.Update(context.Background(), name, options)
+}
+
+// Delete an organization by its name.
+func organizationDelete(client *tfe.Client, name string) error {
+	return client.Organizations.Delete(context.Background(), name)
+}
+
+// Capacity shows the current run capacity of an organization.
+func organizationCapacity(client *tfe.Client, name string) (*tfe.Capacity, error) {
+	return client.Organizations.Capacity(context.Background(), name)
+}
+
+// Entitlements shows the entitlements of an organization.
+func organizationEntitlements(client *tfe.Client, name string) (*tfe.Entitlements, error) {
+	return client.Organizations.Entitlements(context.Background(), name)
+}
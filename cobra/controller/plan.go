@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/sirupsen/logrus"
@@ -30,7 +32,7 @@ import (
 	"gitlab.aws.dev/devops-aws/tecli/helper"
 )
 
-var planValidArgs = []string{"read", "logs"}
+var planValidArgs = []string{"create", "read", "logs", "export", "json", "summary", "check"}
 
 // PlanCmd command to display tecli current version
 func PlanCmd() *cobra.Command {
@@ -63,22 +65,104 @@ func planPreRun(cmd *cobra.Command, args []string) error {
 
 	fArg := args[0]
 	switch fArg {
-	case "read", "logs":
+	case "create":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+		options := aid.GetPlanCreateOptions(cmd)
+		if !options.Speculative {
+			return fmt.Errorf("--speculative must be defined, use run create or deploy to create plans that can be applied")
+		}
+
+		if info, err := os.Stat(options.Path); err != nil || !info.IsDir() {
+			return fmt.Errorf("--path must be an existing directory: %s", options.Path)
+		}
+
+	case "read", "logs", "json":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "plan", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "summary":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "plan", fArg, "id"); err != nil {
+			return err
+		}
+
+		if max := aid.GetPlanMaxDestroy(cmd); max != nil && *max < 0 {
+			return fmt.Errorf("--max-destroy must be zero or greater")
+		}
+
+	case "check":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "plan", fArg, "rules"); err != nil {
+			return err
+		}
+
+		id := helper.GetCmdFlagString(cmd, "id")
+		planFile := helper.GetCmdFlagString(cmd, "plan-file")
+
+		if id == "" && planFile == "" {
+			return fmt.Errorf("either --id or --plan-file must be defined")
+		}
+
+		if id != "" && planFile != "" {
+			return fmt.Errorf("--id and --plan-file are mutually exclusive")
+		}
+
+	case "export":
 		if err := helper.ValidateCmdArgAndFlag(cmd, args, "plan", fArg, "id"); err != nil {
 			return err
 		}
+
+		// --path defaults to the configuration directory of create, the export needs a file
+		if helper.GetCmdFlagString(cmd, "path") == "" {
+			return fmt.Errorf("--path must be defined")
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%v", err)
+		}
+
+		if err := aid.ValidatePlanExportDataType(format); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 func planRun(cmd *cobra.Command, args []string) error {
+	fArg := args[0]
+	if fArg == "check" {
+		// checking a local plan file works offline, the token is only needed to download the plan
+		return planCheck(cmd)
+	}
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
-	fArg := args[0]
 	switch fArg {
+	case "create":
+		// from now on, errors are about the plan itself, not how the command was used
+		cmd.SilenceUsage = true
+
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		plan, err := planCreateSpeculative(client, workspaceID, aid.GetPlanCreateOptions(cmd), aid.GetLogsOptions(cmd))
+		if err != nil {
+			return err
+		}
+
+		if code := aid.GetPlanExitCode(plan); code != 0 {
+			return &aid.ExitError{Code: code, Err: fmt.Errorf("plan %s has changes: %d to add, %d to change, %d to destroy", plan.ID, plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions)}
+		}
+
+		fmt.Fprintf(os.Stderr, "plan %s has no changes\n", plan.ID)
+
 	case "read":
 		id, err := cmd.Flags().GetString("id")
 		if err != nil {
@@ -87,7 +171,7 @@ func planRun(cmd *cobra.Command, args []string) error {
 
 		plan, err := planRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(plan))
+			aid.PrintOutput(output, plan)
 		} else {
 			return fmt.Errorf("plan %s not found\n%v", id, err)
 		}
@@ -101,12 +185,303 @@ func planRun(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			logrus.Fatalf("unable to read plan logs\n%v", err)
 		}
-		fmt.Println(StreamToString(logs))
+
+		if err := printLogs(logs, aid.GetLogsOptions(cmd)); err != nil {
+			return fmt.Errorf("unable to print plan logs\n%v", err)
+		}
+
+	case "export":
+		id := helper.GetCmdFlagString(cmd, "id")
+		path := helper.GetCmdFlagString(cmd, "path")
+		format, _ := cmd.Flags().GetString("format")
+
+		cmd.SilenceUsage = true
+
+		export, err := planExportAndDownload(client, id, tfe.PlanExportDataType(format), aid.GetPlanWaitOptions(cmd))
+		if err != nil {
+			return err
+		}
+
+		// sentinel mocks hold the configuration and the state of the workspace
+		if err := aid.WriteSecretFile(path, export); err != nil {
+			return fmt.Errorf("unable to write the export of plan %s to %s\n%v", id, path, err)
+		}
+
+		fmt.Printf("%s export of plan %s saved successfully to %s\n", format, id, path)
+
+	case "json":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		plan, err := planJSONOutput(aid.NewPlanAPI(token, dao.GetAddress(profile)), id)
+		if err != nil {
+			return fmt.Errorf("unable to download the JSON execution plan of plan %s\n%v", id, err)
+		}
+
+		path := helper.GetCmdFlagString(cmd, "path")
+		if path == "" {
+			_, err := os.Stdout.Write(plan)
+			return err
+		}
+
+		// the plan holds the values of the resources, sensitive ones included
+		if err := aid.WriteSecretFile(path, plan); err != nil {
+			return fmt.Errorf("unable to write the JSON execution plan of plan %s to %s\n%v", id, path, err)
+		}
+
+		fmt.Printf("JSON execution plan of plan %s saved successfully to %s\n", id, path)
+
+	case "summary":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		cmd.SilenceUsage = true
+
+		summary, err := planSummary(client, aid.NewPlanAPI(token, dao.GetAddress(profile)), id)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("output") {
+			aid.PrintOutput(output, summary)
+		} else if err := aid.RenderPlanSummary(os.Stdout, summary); err != nil {
+			return err
+		}
+
+		if max := aid.GetPlanMaxDestroy(cmd); max != nil && summary.Destroys() > *max {
+			return &aid.ExitError{Code: aid.PlanExitMaxDestroy, Err: fmt.Errorf("plan %s destroys %d resources, more than --max-destroy %d", id, summary.Destroys(), *max)}
+		}
 	}
 
 	return nil
 }
 
+// planCheck checks the plan against the rules of --rules
+func planCheck(cmd *cobra.Command) error {
+	// the rules are checked first, there's no point downloading the plan if they are invalid
+	rulesFile := helper.GetCmdFlagString(cmd, "rules")
+	b, err := ioutil.ReadFile(rulesFile)
+	if err != nil {
+		return fmt.Errorf("unable to read rules file %s\n%v", rulesFile, err)
+	}
+
+	rules, err := aid.ParsePlanRules(b)
+	if err != nil {
+		return fmt.Errorf("invalid rules file %s\n%v", rulesFile, err)
+	}
+
+	cmd.SilenceUsage = true
+
+	id, plan, err := planCheckJSON(cmd)
+	if err != nil {
+		return err
+	}
+
+	changes, err := aid.ParsePlanResourceChanges(id, plan)
+	if err != nil {
+		return err
+	}
+
+	violations := aid.CheckPlanRules(rules, changes)
+	if cmd.Flags().Changed("output") {
+		aid.PrintOutput(output, violations)
+	} else if err := aid.RenderPlanRuleViolations(os.Stdout, id, violations); err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return &aid.ExitError{Code: aid.PlanExitRuleViolations, Err: fmt.Errorf("plan %s has %d violations of the rules of %s", id, len(violations), rulesFile)}
+	}
+
+	return nil
+}
+
+// planCheckJSON returns the JSON execution plan read from --plan-file, or downloaded for the plan given by --id.
+// Rules need the attributes of the resources, which the plan logs don't hold, so there's no fallback on logs.
+func planCheckJSON(cmd *cobra.Command) (string, []byte, error) {
+	if planFile := helper.GetCmdFlagString(cmd, "plan-file"); planFile != "" {
+		b, err := ioutil.ReadFile(planFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to read plan file %s\n%v", planFile, err)
+		}
+
+		return planFile, b, nil
+	}
+
+	id := helper.GetCmdFlagString(cmd, "id")
+	b, err := planJSONOutput(aid.NewPlanAPI(dao.GetTeamToken(profile), dao.GetAddress(profile)), id)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to download the JSON execution plan of plan %s, it requires admin access to the workspace\n%v", id, err)
+	}
+
+	return id, b, nil
+}
+
+// planSummary summarizes the plan from its JSON execution plan, or from its logs when the JSON isn't available:
+// the token may not have admin access to the workspace, or the plan was made by a Terraform version older than 0.12.
+func planSummary(client *tfe.Client, api *aid.PlanAPI, planID string) (*aid.PlanSummary, error) {
+	// an unfinished plan has no changes yet, summarizing it would let anything through
+	p, err := planRead(client, planID)
+	if err != nil {
+		return nil, fmt.Errorf("plan %s not found\n%v", planID, err)
+	}
+
+	if p.Status != tfe.PlanFinished {
+		return nil, fmt.Errorf("plan %s is %s, only finished plans can be summarized", planID, p.Status)
+	}
+
+	plan, err := planJSONOutput(api, planID)
+	if err == nil {
+		return aid.ParsePlanJSON(planID, plan)
+	}
+
+	fmt.Fprintf(os.Stderr, "JSON execution plan of plan %s unavailable, summarizing its logs instead\n%v\n", planID, err)
+
+	logs, err := planLogs(client, planID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read plan logs\n%v", err)
+	}
+
+	return aid.ParsePlanLogs(planID, StreamToByte(logs))
+}
+
+// planExportAndDownload exports the plan, waits for the export to finish and returns its content.
+// The export is deleted once downloaded, as it's only needed once.
+func planExportAndDownload(client *tfe.Client, planID string, dataType tfe.PlanExportDataType, options aid.RunWaitOptions) ([]byte, error) {
+	export, err := planExportCreate(client, tfe.PlanExportCreateOptions{
+		Plan:     &tfe.Plan{ID: planID},
+		DataType: &dataType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to export plan %s\n%v", planID, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "plan export %s created, waiting for it to finish\n", export.ID)
+
+	if _, err := planExportWait(client, export.ID, options); err != nil {
+		return nil, err
+	}
+
+	b, err := planExportDownload(client, export.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download plan export %s\n%v", export.ID, err)
+	}
+
+	if err := planExportDelete(client, export.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to delete plan export %s, it expires within an hour\n%v\n", export.ID, err)
+	}
+
+	return b, nil
+}
+
+// planExportWait polls the plan export until it's finished, returns an error if it errored, was canceled or expired
+func planExportWait(client *tfe.Client, planExportID string, options aid.RunWaitOptions) (*tfe.PlanExport, error) {
+	ctx, cancel := options.Context()
+	defer cancel()
+
+	for {
+		export, err := client.PlanExports.Read(ctx, planExportID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for plan export %s", options.Timeout, planExportID)}
+			}
+			return nil, fmt.Errorf("unable to read plan export %s\n%v", planExportID, err)
+		}
+
+		switch export.Status {
+		case tfe.PlanExportFinished:
+			return export, nil
+		case tfe.PlanExportErrored, tfe.PlanExportCanceled, tfe.PlanExportExpired:
+			return nil, fmt.Errorf("plan export %s %s", planExportID, export.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for plan export %s", options.Timeout, planExportID)}
+		case <-time.After(options.PollInterval):
+		}
+	}
+}
+
+// planCreateSpeculative uploads the configuration files as a speculative configuration version,
+// streams the logs of the plan queued for it and returns the plan once the run is finished.
+func planCreateSpeculative(client *tfe.Client, workspaceID string, options aid.PlanCreateOptions, logsOptions aid.LogsOptions) (*tfe.Plan, error) {
+	// the timeout covers every step, from the upload to the end of the plan
+	options.Wait = options.Wait.WithDeadline(time.Now())
+
+	// the upload and the logs are bound to the deadline as well, following the logs lasts as long as the plan
+	ctx, cancel := options.Wait.Context()
+	defer cancel()
+
+	timeoutErr := func(step string) error {
+		return &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s %s", options.Wait.Timeout, step)}
+	}
+
+	cvOptions := tfe.ConfigurationVersionCreateOptions{
+		AutoQueueRuns: tfe.Bool(true),
+		Speculative:   tfe.Bool(true),
+	}
+
+	cv, err := configurationVersionCreate(client, workspaceID, cvOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create configuration version\n%v", err)
+	}
+
+	if err := client.ConfigurationVersions.Upload(ctx, cv.UploadURL, options.Path); err != nil {
+		if ctx.Err() != nil {
+			return nil, timeoutErr(fmt.Sprintf("uploading %s to configuration version %s", options.Path, cv.ID))
+		}
+		return nil, fmt.Errorf("unable to upload %s to configuration version %s\n%v", options.Path, cv.ID, err)
+	}
+
+	if _, err := configurationVersionWaitUploaded(client, cv.ID, options.Wait); err != nil {
+		return nil, err
+	}
+
+	run, err := runWaitQueued(client, workspaceID, cv.ID, options.Wait)
+	if err != nil {
+		return nil, err
+	}
+
+	// the plan logs are only available once the plan started
+	run, err = runWaitUntil(client, run.ID, options.Wait, func(run *tfe.Run) bool {
+		return run.Status != tfe.RunPending && run.Status != tfe.RunPlanQueued
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := client.Plans.Logs(ctx, run.Plan.ID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, timeoutErr(fmt.Sprintf("reading the logs of plan %s", run.Plan.ID))
+		}
+		return nil, fmt.Errorf("unable to read plan logs\n%v", err)
+	}
+
+	logsOptions.Follow = true
+	if err := printLogs(logs, logsOptions); err != nil {
+		if ctx.Err() != nil {
+			return nil, timeoutErr(fmt.Sprintf("following the logs of plan %s", run.Plan.ID))
+		}
+		return nil, fmt.Errorf("unable to print plan logs\n%v", err)
+	}
+
+	run, err = runWait(client, run.ID, options.Wait)
+	if err != nil {
+		return nil, err
+	}
+
+	if code := aid.GetRunExitCode(run.Status); code != 0 {
+		return nil, &aid.ExitError{Code: code, Err: fmt.Errorf("run %s finished with status %s", run.ID, run.Status)}
+	}
+
+	plan, err := planRead(client, run.Plan.ID)
+	if err != nil {
+		return nil, fmt.Errorf("plan %s not found\n%v", run.Plan.ID, err)
+	}
+
+	return plan, nil
+}
+
 // Read a plan by its ID.
 func planRead(client *tfe.Client, planID string) (*tfe.Plan, error) {
 	return client.Plans.Read(context.Background(), planID)
@@ -119,6 +494,43 @@ func planLogs(client *tfe.Client, planID string) (io.Reader, error) {
 
 }
 
+// Download the JSON execution plan of a plan.
+func planJSONOutput(api *aid.PlanAPI, planID string) ([]byte, error) {
+	return api.JSONOutput(context.Background(), planID)
+}
+
+// Create an export of a plan.
+func planExportCreate(client *tfe.Client, options tfe.PlanExportCreateOptions) (*tfe.PlanExport, error) {
+	return client.PlanExports.Create(context.Background(), options)
+}
+
+// Download the content of a finished plan export.
+func planExportDownload(client *tfe.Client, planExportID string) ([]byte, error) {
+	return client.PlanExports.Download(context.Background(), planExportID)
+}
+
+// Delete a plan export by its ID.
+func planExportDelete(client *tfe.Client, planExportID string) error {
+	return client.PlanExports.Delete(context.Background(), planExportID)
+}
+
+// printLogs writes the logs on the standard output. When following, chunks are written as soon as they arrive.
+func printLogs(logs io.Reader, options aid.LogsOptions) error {
+	w := aid.NewLogWriter(os.Stdout, options.NoColor)
+
+	if options.Follow {
+		_, err := io.Copy(w, logs)
+		return err
+	}
+
+	if _, err := w.Write(StreamToByte(logs)); err != nil {
+		return err
+	}
+
+	_, err := fmt.Println()
+	return err
+}
+
 // StreamToByte converts io.Reader to []byte
 func StreamToByte(stream io.Reader) []byte {
 	buf := new(bytes.Buffer)
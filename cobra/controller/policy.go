@@ -0,0 +1,303 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var policyValidArgs = []string{"list", "create", "read", "update", "delete", "upload", "download"}
+
+// PolicyCmd command to manage Sentinel policies
+func PolicyCmd() *cobra.Command {
+	man, err := helper.GetManual("policy", policyValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: policyValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   policyPreRun,
+		RunE:      policyRun,
+	}
+
+	aid.SetPolicyFlags(cmd)
+
+	return cmd
+}
+
+func policyPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "policy"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "organization"); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "organization"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "name"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "enforcement-level"); err != nil {
+			return err
+		}
+
+		// check the file before the policy is created, so a typo doesn't leave a policy without content behind
+		if path := helper.GetCmdFlagString(cmd, "file"); path != "" {
+			if _, err := policyReadFile(path); err != nil {
+				return err
+			}
+		}
+
+	case "read", "update", "delete", "download":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "upload":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "id"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "file"); err != nil {
+			return err
+		}
+
+		if _, err := policyReadFile(helper.GetCmdFlagString(cmd, "file")); err != nil {
+			return err
+		}
+	}
+
+	if level := helper.GetCmdFlagString(cmd, "enforcement-level"); level != "" {
+		if err := aid.ValidateEnforcementLevel(level); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func policyRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		list, err := policyList(client, aid.GetPolicyListOptions(cmd), aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintPolicyList(list, output)
+		} else {
+			return fmt.Errorf("no policy was found\n%v", err)
+		}
+
+	case "create":
+		policy, err := policyCreate(client, aid.GetPolicyCreateOptions(cmd))
+		if err != nil || policy.ID == "" {
+			return fmt.Errorf("unable to create policy\n%v", err)
+		}
+
+		// upload the policy content right away if a file was given
+		if path := helper.GetCmdFlagString(cmd, "file"); path != "" {
+			if err := policyUploadFile(client, policy.ID, path); err != nil {
+				cmd.SilenceUsage = true
+
+				// a policy without content would fail every policy check it's enforced by
+				if deleteErr := policyDelete(client, policy.ID); deleteErr != nil {
+					return fmt.Errorf("%v\npolicy %s was created without content, and couldn't be deleted, delete it with: tecli policy delete --id %s\n%v", err, policy.ID, policy.ID, deleteErr)
+				}
+
+				return fmt.Errorf("%v\npolicy %s was deleted", err, policy.ID)
+			}
+		}
+
+		aid.PrintOutput(output, policy)
+
+	case "read":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		policy, err := policyRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, policy)
+		} else {
+			return fmt.Errorf("policy %s not found\n%v", id, err)
+		}
+
+	case "update":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		current, err := policyRead(client, id)
+		if err != nil {
+			return fmt.Errorf("policy %s not found\n%v", id, err)
+		}
+
+		path := current.Name + ".sentinel"
+		if len(current.Enforce) > 0 {
+			path = current.Enforce[0].Path
+		}
+
+		policy, err := policyUpdate(client, id, aid.GetPolicyUpdateOptions(cmd, path))
+		if err == nil && policy.ID != "" {
+			aid.PrintOutput(output, policy)
+		} else {
+			return fmt.Errorf("unable to update policy\n%v", err)
+		}
+
+	case "delete":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		err := policyDelete(client, id)
+		if err == nil {
+			fmt.Printf("policy %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete policy %s\n%v", id, err)
+		}
+
+	case "upload":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		if err := policyUploadFile(client, id, helper.GetCmdFlagString(cmd, "file")); err != nil {
+			return err
+		}
+
+		fmt.Printf("policy %s uploaded successfully\n", id)
+
+	case "download":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		content, err := policyDownload(client, id)
+		if err != nil {
+			return fmt.Errorf("unable to download policy %s\n%v", id, err)
+		}
+
+		path := helper.GetCmdFlagString(cmd, "file")
+		if path == "" {
+			fmt.Print(string(content))
+			return nil
+		}
+
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("unable to write policy %s to %s\n%v", id, path, err)
+		}
+
+		fmt.Printf("policy %s downloaded successfully to %s\n", id, path)
+	}
+
+	return nil
+}
+
+// policyReadFile reads the Sentinel file at path, an empty file isn't a policy
+func policyReadFile(path string) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file %s\n%v", path, err)
+	}
+
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return nil, fmt.Errorf("file %s is empty, it doesn't contain a policy", path)
+	}
+
+	return content, nil
+}
+
+// policyUploadFile uploads the content of the Sentinel file at path
+func policyUploadFile(client *tfe.Client, policyID string, path string) error {
+	content, err := policyReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := policyUpload(client, policyID, content); err != nil {
+		return fmt.Errorf("unable to upload policy %s\n%v", policyID, err)
+	}
+
+	return nil
+}
+
+// List all the policies of the organization.
+func policyList(client *tfe.Client, options tfe.PolicyListOptions, pagination aid.PaginationOptions) (*tfe.PolicyList, error) {
+	list := &tfe.PolicyList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.Policies.List(context.Background(), organization, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a policy and associate it with the organization.
+func policyCreate(client *tfe.Client, options tfe.PolicyCreateOptions) (*tfe.Policy, error) {
+	return client.Policies.Create(context.Background(), organization, options)
+}
+
+// Read a policy by its ID.
+func policyRead(client *tfe.Client, policyID string) (*tfe.Policy, error) {
+	return client.Policies.Read(context.Background(), policyID)
+}
+
+// Update an existing policy.
+func policyUpdate(client *tfe.Client, policyID string, options tfe.PolicyUpdateOptions) (*tfe.Policy, error) {
+	return client.Policies.Update(context.Background(), policyID, options)
+}
+
+// Delete a policy by its ID.
+func policyDelete(client *tfe.Client, policyID string) error {
+	return client.Policies.Delete(context.Background(), policyID)
+}
+
+// Upload the policy content of the policy.
+func policyUpload(client *tfe.Client, policyID string, content []byte) error {
+	return client.Policies.Upload(context.Background(), policyID, content)
+}
+
+// Download the policy content of the policy.
+func policyDownload(client *tfe.Client, policyID string) ([]byte, error) {
+	return client.Policies.Download(context.Background(), policyID)
+}
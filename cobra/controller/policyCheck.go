@@ -0,0 +1,186 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var policyCheckValidArgs = []string{"list", "read", "logs", "override"}
+
+// PolicyCheckCmd command to inspect and override the policy checks of a run
+func PolicyCheckCmd() *cobra.Command {
+	man, err := helper.GetManual("policy-check", policyCheckValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: policyCheckValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   policyCheckPreRun,
+		RunE:      policyCheckRun,
+	}
+
+	aid.SetPolicyCheckFlags(cmd)
+
+	return cmd
+}
+
+func policyCheckPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "policy-check"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-check", fArg, "run-id"); err != nil {
+			return err
+		}
+
+	case "read", "logs", "override":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-check", fArg, "id"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func policyCheckRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		runID := helper.GetCmdFlagString(cmd, "run-id")
+
+		list, err := policyCheckList(client, runID, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintPolicyCheckList(list, output)
+		} else {
+			return fmt.Errorf("no policy check was found\n%v", err)
+		}
+
+	case "read":
+		check, err := policyCheckRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, check)
+		} else {
+			return fmt.Errorf("policy check %s not found\n%v", id, err)
+		}
+
+	case "logs":
+		logs, err := policyCheckLogs(client, id)
+		if err != nil {
+			return fmt.Errorf("unable to read policy check logs\n%v", err)
+		}
+
+		if err := printLogs(logs, aid.GetLogsOptions(cmd)); err != nil {
+			return fmt.Errorf("unable to print policy check logs\n%v", err)
+		}
+
+	case "override":
+		check, err := policyCheckOverride(client, id)
+		if err == nil {
+			aid.PrintOutput(output, check)
+		} else {
+			return fmt.Errorf("unable to override policy check %s\n%v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// policyCheckOverrideSoftFailed overrides every soft failed policy check of the run
+func policyCheckOverrideSoftFailed(client *tfe.Client, runID string) error {
+	list, err := policyCheckList(client, runID, aid.PaginationOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list policy checks of run %s\n%v", runID, err)
+	}
+
+	for _, check := range list.Items {
+		if check.Status != tfe.PolicySoftFailed {
+			continue
+		}
+
+		if !aid.IsPolicyCheckOverridable(check) {
+			return fmt.Errorf("policy check %s of run %s can't be overridden", check.ID, runID)
+		}
+
+		if _, err := policyCheckOverride(client, check.ID); err != nil {
+			return fmt.Errorf("unable to override policy check %s\n%v", check.ID, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "policy check %s overridden\n", check.ID)
+	}
+
+	return nil
+}
+
+// List all policy checks of the given run.
+func policyCheckList(client *tfe.Client, runID string, pagination aid.PaginationOptions) (*tfe.PolicyCheckList, error) {
+	list := &tfe.PolicyCheckList{}
+	options := tfe.PolicyCheckListOptions{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.PolicyChecks.List(context.Background(), runID, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Read a policy check by its ID.
+func policyCheckRead(client *tfe.Client, policyCheckID string) (*tfe.PolicyCheck, error) {
+	return client.PolicyChecks.Read(context.Background(), policyCheckID)
+}
+
+// Override a soft-mandatory or warning policy.
+func policyCheckOverride(client *tfe.Client, policyCheckID string) (*tfe.PolicyCheck, error) {
+	return client.PolicyChecks.Override(context.Background(), policyCheckID)
+}
+
+// Logs retrieves the logs of a policy check.
+func policyCheckLogs(client *tfe.Client, policyCheckID string) (io.Reader, error) {
+	return client.PolicyChecks.Logs(context.Background(), policyCheckID)
+}
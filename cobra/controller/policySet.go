@@ -0,0 +1,302 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var policySetValidArgs = []string{
+	"list",
+	"create",
+	"read",
+	"update",
+	"delete",
+	"add-policies",
+	"remove-policies",
+	"add-workspaces",
+	"remove-workspaces",
+}
+
+// PolicySetCmd command to manage policy sets
+func PolicySetCmd() *cobra.Command {
+	man, err := helper.GetManual("policy-set", policySetValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: policySetValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   policySetPreRun,
+		RunE:      policySetRun,
+	}
+
+	aid.SetPolicySetFlags(cmd)
+
+	return cmd
+}
+
+func policySetPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "policy-set"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "organization"); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "organization"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "name"); err != nil {
+			return err
+		}
+
+	default:
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "id"); err != nil {
+			return err
+		}
+	}
+
+	ids, names := aid.GetPolicySetWorkspaces(cmd)
+	switch fArg {
+	case "add-policies", "remove-policies":
+		if len(aid.GetPolicySetPolicyIDs(cmd)) == 0 {
+			return fmt.Errorf("--policy-id must be defined")
+		}
+
+	case "add-workspaces", "remove-workspaces":
+		if len(ids) == 0 && len(names) == 0 {
+			return fmt.Errorf("either --workspace-id or --workspace must be defined")
+		}
+	}
+
+	if len(names) > 0 && organization == "" {
+		return fmt.Errorf("--organization must be defined when using --workspace")
+	}
+
+	return nil
+}
+
+func policySetRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		list, err := policySetList(client, aid.GetPolicySetListOptions(cmd), aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintPolicySetList(list, output)
+		} else {
+			return fmt.Errorf("no policy set was found\n%v", err)
+		}
+
+	case "create":
+		options := aid.GetPolicySetCreateOptions(cmd)
+
+		workspaces, err := policySetWorkspaces(client, cmd)
+		if err != nil {
+			return err
+		}
+		options.Workspaces = workspaces
+
+		policySet, err := policySetCreate(client, options)
+		if err == nil && policySet.ID != "" {
+			aid.PrintOutput(output, policySet)
+		} else {
+			return fmt.Errorf("unable to create policy set\n%v", err)
+		}
+
+	case "read":
+		policySet, err := policySetRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, policySet)
+		} else {
+			return fmt.Errorf("policy set %s not found\n%v", id, err)
+		}
+
+	case "update":
+		policySet, err := policySetUpdate(client, id, aid.GetPolicySetUpdateOptions(cmd))
+		if err == nil && policySet.ID != "" {
+			aid.PrintOutput(output, policySet)
+		} else {
+			return fmt.Errorf("unable to update policy set\n%v", err)
+		}
+
+	case "delete":
+		err := policySetDelete(client, id)
+		if err == nil {
+			fmt.Printf("policy set %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete policy set %s\n%v", id, err)
+		}
+
+	case "add-policies", "remove-policies":
+		var policies []*tfe.Policy
+		for _, policyID := range aid.GetPolicySetPolicyIDs(cmd) {
+			policies = append(policies, &tfe.Policy{ID: policyID})
+		}
+
+		var err error
+		if fArg == "add-policies" {
+			err = policySetAddPolicies(client, id, tfe.PolicySetAddPoliciesOptions{Policies: policies})
+		} else {
+			err = policySetRemovePolicies(client, id, tfe.PolicySetRemovePoliciesOptions{Policies: policies})
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to %s policies of policy set %s\n%v", policySetVerb(fArg), id, err)
+		}
+
+		fmt.Printf("%d policies %s policy set %s successfully\n", len(policies), policySetResult(fArg), id)
+
+	case "add-workspaces", "remove-workspaces":
+		workspaces, err := policySetWorkspaces(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		if fArg == "add-workspaces" {
+			err = policySetAddWorkspaces(client, id, tfe.PolicySetAddWorkspacesOptions{Workspaces: workspaces})
+		} else {
+			err = policySetRemoveWorkspaces(client, id, tfe.PolicySetRemoveWorkspacesOptions{Workspaces: workspaces})
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to %s workspaces of policy set %s\n%v", policySetVerb(fArg), id, err)
+		}
+
+		fmt.Printf("%d workspaces %s policy set %s successfully\n", len(workspaces), policySetResult(fArg), id)
+	}
+
+	return nil
+}
+
+// policySetWorkspaces returns the workspaces given by ID and by name, names are resolved within the organization
+func policySetWorkspaces(client *tfe.Client, cmd *cobra.Command) ([]*tfe.Workspace, error) {
+	ids, names := aid.GetPolicySetWorkspaces(cmd)
+
+	var workspaces []*tfe.Workspace
+	for _, id := range ids {
+		workspaces = append(workspaces, &tfe.Workspace{ID: id})
+	}
+
+	for _, name := range names {
+		workspace, err := workspaceRead(client, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find workspace %s in organization %s\n%v", name, organization, err)
+		}
+		workspaces = append(workspaces, &tfe.Workspace{ID: workspace.ID})
+	}
+
+	return workspaces, nil
+}
+
+func policySetVerb(arg string) string {
+	if arg == "add-policies" || arg == "add-workspaces" {
+		return "add"
+	}
+	return "remove"
+}
+
+func policySetResult(arg string) string {
+	if policySetVerb(arg) == "add" {
+		return "added to"
+	}
+	return "removed from"
+}
+
+// List all the policy sets of the organization.
+func policySetList(client *tfe.Client, options tfe.PolicySetListOptions, pagination aid.PaginationOptions) (*tfe.PolicySetList, error) {
+	list := &tfe.PolicySetList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.PolicySets.List(context.Background(), organization, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a policy set and associate it with the organization.
+func policySetCreate(client *tfe.Client, options tfe.PolicySetCreateOptions) (*tfe.PolicySet, error) {
+	return client.PolicySets.Create(context.Background(), organization, options)
+}
+
+// Read a policy set by its ID.
+func policySetRead(client *tfe.Client, policySetID string) (*tfe.PolicySet, error) {
+	return client.PolicySets.Read(context.Background(), policySetID)
+}
+
+// Update an existing policy set.
+func policySetUpdate(client *tfe.Client, policySetID string, options tfe.PolicySetUpdateOptions) (*tfe.PolicySet, error) {
+	return client.PolicySets.Update(context.Background(), policySetID, options)
+}
+
+// Delete a policy set by its ID.
+func policySetDelete(client *tfe.Client, policySetID string) error {
+	return client.PolicySets.Delete(context.Background(), policySetID)
+}
+
+// Add policies to a policy set.
+func policySetAddPolicies(client *tfe.Client, policySetID string, options tfe.PolicySetAddPoliciesOptions) error {
+	return client.PolicySets.AddPolicies(context.Background(), policySetID, options)
+}
+
+// Remove policies from a policy set.
+func policySetRemovePolicies(client *tfe.Client, policySetID string, options tfe.PolicySetRemovePoliciesOptions) error {
+	return client.PolicySets.RemovePolicies(context.Background(), policySetID, options)
+}
+
+// Add workspaces to a policy set.
+func policySetAddWorkspaces(client *tfe.Client, policySetID string, options tfe.PolicySetAddWorkspacesOptions) error {
+	return client.PolicySets.AddWorkspaces(context.Background(), policySetID, options)
+}
+
+// Remove workspaces from a policy set.
+func policySetRemoveWorkspaces(client *tfe.Client, policySetID string, options tfe.PolicySetRemoveWorkspacesOptions) error {
+	return client.PolicySets.RemoveWorkspaces(context.Background(), policySetID, options)
+}
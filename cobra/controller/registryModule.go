@@ -0,0 +1,270 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var registryModuleValidArgs = []string{"list", "create", "create-version", "upload", "read", "delete"}
+
+// RegistryModuleCmd command to manage the modules of the private module registry
+func RegistryModuleCmd() *cobra.Command {
+	man, err := helper.GetManual("registry-module", registryModuleValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: registryModuleValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   registryModulePreRun,
+		RunE:      registryModuleRun,
+	}
+
+	aid.SetRegistryModuleFlags(cmd)
+
+	return cmd
+}
+
+func registryModulePreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "registry-module"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	if err := helper.ValidateCmdArgAndFlag(cmd, args, "registry-module", fArg, "organization"); err != nil {
+		return err
+	}
+
+	// the flags identifying the module, and its version, required by each argument
+	var required []string
+	switch fArg {
+	case "create":
+		if helper.GetCmdFlagString(cmd, "identifier") != "" {
+			required = []string{"oauth-token-id"}
+		} else {
+			required = []string{"name", "provider"}
+		}
+
+	case "create-version":
+		required = []string{"name", "provider", "version"}
+
+	case "upload":
+		required = []string{"name", "provider", "version", "path"}
+
+	case "read":
+		required = []string{"name", "provider"}
+
+	case "delete":
+		required = []string{"name"}
+		if helper.GetCmdFlagString(cmd, "version") != "" {
+			required = append(required, "provider")
+		}
+	}
+
+	for _, flag := range required {
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "registry-module", fArg, flag); err != nil {
+			return err
+		}
+	}
+
+	if fArg == "upload" {
+		if err := aid.ValidateRegistryModuleDir(helper.GetCmdFlagString(cmd, "path")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func registryModuleRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+	api := aid.NewRegistryModuleAPI(token, dao.GetAddress(profile))
+
+	name := helper.GetCmdFlagString(cmd, "name")
+	provider := helper.GetCmdFlagString(cmd, "provider")
+	version := helper.GetCmdFlagString(cmd, "version")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		list, err := registryModuleList(api, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintRegistryModuleList(list, output)
+		} else {
+			return fmt.Errorf("no registry module was found\n%v", err)
+		}
+
+	case "create":
+		var module *tfe.RegistryModule
+		var err error
+		if helper.GetCmdFlagString(cmd, "identifier") != "" {
+			module, err = registryModuleCreateWithVCSConnection(client, aid.GetRegistryModuleCreateWithVCSConnectionOptions(cmd))
+		} else {
+			module, err = registryModuleCreate(client, aid.GetRegistryModuleCreateOptions(cmd))
+		}
+
+		if err == nil && module.ID != "" {
+			aid.PrintOutput(output, module)
+		} else {
+			return fmt.Errorf("unable to create registry module\n%v", err)
+		}
+
+	case "create-version":
+		mv, err := registryModuleCreateVersion(api, name, provider, version)
+		if err == nil && mv.ID != "" {
+			aid.PrintOutput(output, mv)
+		} else {
+			return fmt.Errorf("unable to create version %s of registry module %s/%s\n%v", version, name, provider, err)
+		}
+
+	case "upload":
+		path := helper.GetCmdFlagString(cmd, "path")
+
+		mv, err := registryModuleCreateVersion(api, name, provider, version)
+		if err != nil || mv.ID == "" {
+			return fmt.Errorf("unable to create version %s of registry module %s/%s\n%v", version, name, provider, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "version %s of registry module %s/%s created, uploading %s\n", version, name, provider, path)
+
+		if err := registryModuleUpload(api, mv.UploadURL, path); err != nil {
+			cmd.SilenceUsage = true
+			err = fmt.Errorf("unable to upload %s to version %s of registry module %s/%s\n%v", path, version, name, provider, err)
+
+			// the pending version would block uploading the same version again
+			if deleteErr := registryModuleDeleteVersion(client, name, provider, version); deleteErr != nil {
+				return fmt.Errorf("%v\nversion %s is left pending, and couldn't be deleted, delete it with: tecli registry-module delete --organization %s --name %s --provider %s --version %s\n%v", err, version, organization, name, provider, version, deleteErr)
+			}
+
+			return fmt.Errorf("%v\npending version %s was deleted", err, version)
+		}
+
+		fmt.Fprintf(os.Stderr, "%s uploaded successfully\n", path)
+		aid.PrintOutput(output, mv.RegistryModuleVersion)
+
+	case "read":
+		module, err := registryModuleRead(client, name, provider)
+		if err == nil {
+			aid.PrintOutput(output, module)
+		} else {
+			return fmt.Errorf("registry module %s/%s not found\n%v", name, provider, err)
+		}
+
+	case "delete":
+		switch {
+		case version != "":
+			err := registryModuleDeleteVersion(client, name, provider, version)
+			if err == nil {
+				fmt.Printf("version %s of registry module %s/%s deleted successfully\n", version, name, provider)
+			} else {
+				return fmt.Errorf("unable to delete version %s of registry module %s/%s\n%v", version, name, provider, err)
+			}
+
+		case provider != "":
+			err := registryModuleDeleteProvider(client, name, provider)
+			if err == nil {
+				fmt.Printf("registry module %s/%s deleted successfully\n", name, provider)
+			} else {
+				return fmt.Errorf("unable to delete registry module %s/%s\n%v", name, provider, err)
+			}
+
+		default:
+			err := registryModuleDelete(client, name)
+			if err == nil {
+				fmt.Printf("registry module %s deleted successfully\n", name)
+			} else {
+				return fmt.Errorf("unable to delete registry module %s\n%v", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// List all the registry modules of the organization.
+func registryModuleList(api *aid.RegistryModuleAPI, pagination aid.PaginationOptions) (*aid.RegistryModuleList, error) {
+	list := &aid.RegistryModuleList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		page, err := api.List(context.Background(), organization, listOptions)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a registry module whose versions are uploaded, instead of published from a VCS repository.
+func registryModuleCreate(client *tfe.Client, options tfe.RegistryModuleCreateOptions) (*tfe.RegistryModule, error) {
+	return client.RegistryModules.Create(context.Background(), organization, options)
+}
+
+// Create a registry module published from the tags of a VCS repository.
+func registryModuleCreateWithVCSConnection(client *tfe.Client, options tfe.RegistryModuleCreateWithVCSConnectionOptions) (*tfe.RegistryModule, error) {
+	return client.RegistryModules.CreateWithVCSConnection(context.Background(), options)
+}
+
+// Create a version of a registry module, along with the link its content is uploaded to.
+func registryModuleCreateVersion(api *aid.RegistryModuleAPI, name string, provider string, version string) (*aid.RegistryModuleVersion, error) {
+	return api.CreateVersion(context.Background(), organization, name, provider, version)
+}
+
+// Upload the module directory to the upload link of a registry module version.
+func registryModuleUpload(api *aid.RegistryModuleAPI, uploadURL string, path string) error {
+	return api.Upload(context.Background(), uploadURL, path)
+}
+
+// Read a registry module by its name and provider.
+func registryModuleRead(client *tfe.Client, name string, provider string) (*tfe.RegistryModule, error) {
+	return client.RegistryModules.Read(context.Background(), organization, name, provider)
+}
+
+// Delete a registry module, every provider and version included.
+func registryModuleDelete(client *tfe.Client, name string) error {
+	return client.RegistryModules.Delete(context.Background(), organization, name)
+}
+
+// Delete a provider of a registry module, every version included.
+func registryModuleDeleteProvider(client *tfe.Client, name string, provider string) error {
+	return client.RegistryModules.DeleteProvider(context.Background(), organization, name, provider)
+}
+
+// Delete a version of a registry module.
+func registryModuleDeleteVersion(client *tfe.Client, name string, provider string, version string) error {
+	return client.RegistryModules.DeleteVersion(context.Background(), organization, name, provider, version)
+}
@@ -20,6 +20,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
 	"gitlab.aws.dev/devops-aws/tecli/helper"
 )
 
@@ -28,6 +29,8 @@ var profile string
 // var config string
 var organization string
 
+var output string
+
 // RootCmd represents the base command when called without any subcommands
 func RootCmd() *cobra.Command {
 	man, err := helper.GetManual("root", []string{})
@@ -37,12 +40,18 @@ func RootCmd() *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Short: man.Short,
-		Long:  man.Long,
+		Short:             man.Short,
+		Long:              man.Long,
+		PersistentPreRunE: rootPersistentPreRun,
 	}
 
 	cmd.PersistentFlags().StringVarP(&profile, "profile", "p", "default", "Use a specific profile from your credentials and configurations file.")
 	cmd.PersistentFlags().StringVarP(&organization, "organization", "o", "", "Terraform Cloud Organization name")
+	cmd.PersistentFlags().StringVar(&output, "output", aid.OutputJSON, aid.OutputUsage)
 
 	return cmd
 }
+
+func rootPersistentPreRun(cmd *cobra.Command, args []string) error {
+	return aid.ValidateOutputFormat(output)
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/spf13/cobra"
@@ -71,32 +72,48 @@ func runPreRun(cmd *cobra.Command, args []string) error {
 
 	fArg := args[0]
 	switch fArg {
-	case "list", "create":
-		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run", fArg, "workspace-id"); err != nil {
+	case "list", "create", "cancel-all", "force-cancel-all", "discard-all":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
 			return err
 		}
 
 	}
 
+	if fArg == "create" {
+		overrideSoftFail, err := cmd.Flags().GetBool("override-soft-fail")
+		if err != nil {
+			return fmt.Errorf("unable to get flag override-soft-fail\n%v", err)
+		}
+
+		wait, err := cmd.Flags().GetBool("wait")
+		if err != nil {
+			return fmt.Errorf("unable to get flag wait\n%v", err)
+		}
+
+		if overrideSoftFail && !wait {
+			return fmt.Errorf("--override-soft-fail requires --wait")
+		}
+	}
+
 	return nil
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
+		list, err := runList(client, workspaceID, tfe.RunListOptions{}, aid.GetPaginationOptions(cmd))
 		if err == nil {
-			aid.PrintRunList(list)
+			aid.PrintRunList(list, output)
 		} else {
 			return fmt.Errorf("no run was found")
 		}
@@ -104,18 +121,16 @@ func runRun(cmd *cobra.Command, args []string) error {
 	case "create":
 		options := aid.GetRunCreateOptions(cmd)
 
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
-		if workspaceID != "" {
-			workspace, err := workspaceReadByID(client, workspaceID)
-			if err != nil {
-				return fmt.Errorf("unable to find workspace %s\n%v", workspaceID, err)
-			}
-			options.Workspace = workspace
+		workspace, err := workspaceReadByID(client, workspaceID)
+		if err != nil {
+			return fmt.Errorf("unable to find workspace %s\n%v", workspaceID, err)
 		}
+		options.Workspace = workspace
 
 		cvID, err := cmd.Flags().GetString("configuration-version-id")
 		if err != nil {
@@ -134,22 +149,76 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 
 		run, err := runCreate(client, options)
-
-		if err == nil && run.ID != "" {
-			fmt.Println(aid.ToJSON(run))
-		} else {
+		if err != nil || run.ID == "" {
 			return fmt.Errorf("unable to create run\n%v", err)
 		}
 
+		waitOptions := aid.GetRunWaitOptions(cmd)
+		if !waitOptions.Wait {
+			aid.PrintOutput(output, run)
+			return nil
+		}
+
+		// from now on, errors are about the run itself, not how the command was used
+		cmd.SilenceUsage = true
+
+		start := time.Now()
+		run, err = runWait(client, run.ID, waitOptions)
+		if err != nil {
+			return err
+		}
+
+		overrideSoftFail, err := cmd.Flags().GetBool("override-soft-fail")
+		if err != nil {
+			return fmt.Errorf("unable to get flag override-soft-fail\n%v", err)
+		}
+
+		if aid.IsRunPolicyOverridable(run.Status) && overrideSoftFail {
+			if err := policyCheckOverrideSoftFailed(client, run.ID); err != nil {
+				return err
+			}
+
+			// the timeout covers the whole wait, not each part of it
+			if waitOptions.Timeout > 0 {
+				waitOptions.Timeout -= time.Since(start)
+				if waitOptions.Timeout <= 0 {
+					return &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out waiting for run %s, last status: %s", run.ID, run.Status)}
+				}
+			}
+
+			run, err = runWaitOverridden(client, run.ID, waitOptions)
+			if err != nil {
+				return err
+			}
+		}
+
+		aid.PrintOutput(output, run)
+		return runWaitExitError(run)
+
 	case "read":
 		id, err := cmd.Flags().GetString("id")
 		if err != nil {
 			return fmt.Errorf("unable to get flag id\n%v", err)
 		}
 
+		policySummary, err := cmd.Flags().GetBool("policy-summary")
+		if err != nil {
+			return fmt.Errorf("unable to get flag policy-summary\n%v", err)
+		}
+
+		if policySummary {
+			list, err := policyCheckList(client, id, aid.PaginationOptions{})
+			if err != nil {
+				return fmt.Errorf("unable to list policy checks of run %s\n%v", id, err)
+			}
+
+			aid.PrintOutput(output, aid.GetPolicyCheckSummary(list.Items))
+			return nil
+		}
+
 		run, err := runRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(run))
+			aid.PrintOutput(output, run)
 		} else {
 			return fmt.Errorf("run %s not found\n%v", id, err)
 		}
@@ -162,7 +231,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 		options := aid.GetRunReadOptions(cmd)
 		run, err := runReadWithOptions(client, id, &options)
 		if err == nil {
-			fmt.Println(aid.ToJSON(run))
+			aid.PrintOutput(output, run)
 		} else {
 			return fmt.Errorf("run %s not found\n%v", id, err)
 		}
@@ -193,12 +262,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 		fmt.Println("run cancelled successfully")
 	case "cancel-all":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
+		list, err := runList(client, workspaceID, tfe.RunListOptions{}, aid.PaginationOptions{})
 		if err == nil {
 			for _, r := range list.Items {
 				if r.Actions.IsCancelable {
@@ -230,12 +299,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 		fmt.Println("run cancelled successfully")
 	case "force-cancel-all":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
+		list, err := runList(client, workspaceID, tfe.RunListOptions{}, aid.PaginationOptions{})
 		if err == nil {
 			for _, r := range list.Items {
 				if r.Actions.IsForceCancelable {
@@ -264,12 +333,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to discard run\n%v", err)
 		}
 	case "discard-all":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%v", err)
+			return err
 		}
 
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
+		list, err := runList(client, workspaceID, tfe.RunListOptions{}, aid.PaginationOptions{})
 		if err == nil {
 			for _, r := range list.Items {
 				if r.Actions.IsDiscardable {
@@ -290,8 +359,22 @@ func runRun(cmd *cobra.Command, args []string) error {
 }
 
 // List all the runs of the given workspace.
-func runList(client *tfe.Client, workspaceID string, options tfe.RunListOptions) (*tfe.RunList, error) {
-	return client.Runs.List(context.Background(), workspaceID, options)
+func runList(client *tfe.Client, workspaceID string, options tfe.RunListOptions, pagination aid.PaginationOptions) (*tfe.RunList, error) {
+	list := &tfe.RunList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.Runs.List(context.Background(), workspaceID, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 // Create a new run with the given options.
@@ -327,3 +410,96 @@ func runForceCancel(client *tfe.Client, runID string, options tfe.RunForceCancel
 func runDiscard(client *tfe.Client, runID string, options tfe.RunDiscardOptions) error {
 	return client.Runs.Discard(context.Background(), runID, options)
 }
+
+// Wait polls the run until it reaches a final status, printing every status transition.
+func runWait(client *tfe.Client, runID string, options aid.RunWaitOptions) (*tfe.Run, error) {
+	return runWaitUntil(client, runID, options, func(run *tfe.Run) bool {
+		return aid.IsRunWaitOver(run)
+	})
+}
+
+// WaitExitError returns the error matching how the wait for the run ended, nil if the run finished successfully.
+func runWaitExitError(run *tfe.Run) error {
+	code := aid.GetRunWaitExitCode(run)
+	switch code {
+	case 0:
+		return nil
+	case aid.RunExitNeedsConfirmation:
+		fmt.Fprintf(os.Stderr, "run %s needs confirmation, apply it with: tecli run apply --id %s\n", run.ID, run.ID)
+		return &aid.ExitError{Code: code, Err: fmt.Errorf("run %s is waiting for confirmation with status %s", run.ID, run.Status)}
+	}
+
+	return &aid.ExitError{Code: code, Err: fmt.Errorf("run %s finished with status %s", run.ID, run.Status)}
+}
+
+// WaitOverridden polls the run after its policy checks were overridden, it keeps its policy_override or policy_soft_failed status until the override is processed.
+func runWaitOverridden(client *tfe.Client, runID string, options aid.RunWaitOptions) (*tfe.Run, error) {
+	return runWaitUntil(client, runID, options, func(run *tfe.Run) bool {
+		return !aid.IsRunPolicyOverridable(run.Status) && aid.IsRunWaitOver(run)
+	})
+}
+
+// WaitUntil polls the run until done returns true, printing every status transition.
+func runWaitUntil(client *tfe.Client, runID string, options aid.RunWaitOptions, done func(run *tfe.Run) bool) (*tfe.Run, error) {
+	ctx, cancel := options.Context()
+	defer cancel()
+
+	timeoutErr := func(status tfe.RunStatus) error {
+		return &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for run %s, last status: %s", options.Timeout, runID, status)}
+	}
+
+	var status tfe.RunStatus
+	for {
+		run, err := client.Runs.Read(ctx, runID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, timeoutErr(status)
+			}
+			return nil, fmt.Errorf("unable to read run %s\n%v", runID, err)
+		}
+
+		if run.Status != status {
+			status = run.Status
+			fmt.Fprintf(os.Stderr, "run %s status: %s\n", run.ID, status)
+		}
+
+		if done(run) {
+			return run, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, timeoutErr(status)
+		case <-time.After(options.PollInterval):
+		}
+	}
+}
+
+// WaitQueued polls the workspace runs until the run using the given configuration version is queued.
+func runWaitQueued(client *tfe.Client, workspaceID string, cvID string, options aid.RunWaitOptions) (*tfe.Run, error) {
+	ctx, cancel := options.Context()
+	defer cancel()
+
+	for {
+		list, err := client.Runs.List(ctx, workspaceID, tfe.RunListOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for a run using configuration version %s", options.Timeout, cvID)}
+			}
+			return nil, fmt.Errorf("unable to list runs of workspace %s\n%v", workspaceID, err)
+		}
+
+		for _, r := range list.Items {
+			if r.ConfigurationVersion != nil && r.ConfigurationVersion.ID == cvID {
+				fmt.Fprintf(os.Stderr, "run %s queued\n", r.ID)
+				return r, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, &aid.ExitError{Code: aid.RunExitTimeout, Err: fmt.Errorf("timed out after %s waiting for a run using configuration version %s", options.Timeout, cvID)}
+		case <-time.After(options.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,285 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var runTriggerValidArgs = []string{"list", "create", "read", "delete", "graph"}
+
+// RunTriggerCmd command to manage the run triggers between workspaces
+func RunTriggerCmd() *cobra.Command {
+	man, err := helper.GetManual("run-trigger", runTriggerValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: runTriggerValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   runTriggerPreRun,
+		RunE:      runTriggerRun,
+	}
+
+	aid.SetRunTriggerFlags(cmd)
+
+	return cmd
+}
+
+func runTriggerPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "run-trigger"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+		if err := aid.ValidateRunTriggerType(helper.GetCmdFlagString(cmd, "type")); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+		if err := validateRunTriggerSource(cmd); err != nil {
+			return err
+		}
+
+	case "read", "delete":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run-trigger", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "graph":
+		if organization == "" {
+			return fmt.Errorf("--organization must be defined")
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%v", err)
+		}
+
+		if err := aid.ValidateRunTriggerGraphFormat(format); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runTriggerRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	id := helper.GetCmdFlagString(cmd, "id")
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		pagination := aid.GetPaginationOptions(cmd)
+		types := []string{"inbound", "outbound"}
+		if t := helper.GetCmdFlagString(cmd, "type"); t != "" {
+			types = []string{t}
+		}
+
+		list := &tfe.RunTriggerList{}
+		for _, t := range types {
+			page, err := runTriggerList(client, workspaceID, t, pagination)
+			if err != nil {
+				return fmt.Errorf("no run trigger was found\n%v", err)
+			}
+
+			list.Pagination = page.Pagination
+			list.Items = append(list.Items, page.Items...)
+		}
+
+		list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+		aid.PrintRunTriggerList(list, output)
+
+	case "create":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		sourceID, err := runTriggerSourceLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		rt, err := runTriggerCreate(client, workspaceID, sourceID)
+		if err == nil && rt.ID != "" {
+			aid.PrintOutput(output, rt)
+		} else {
+			return fmt.Errorf("unable to create run trigger\n%v", err)
+		}
+
+	case "read":
+		rt, err := runTriggerRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, rt)
+		} else {
+			return fmt.Errorf("run trigger %s not found\n%v", id, err)
+		}
+
+	case "delete":
+		err := runTriggerDelete(client, id)
+		if err == nil {
+			fmt.Printf("run trigger %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete run trigger %s\n%v", id, err)
+		}
+
+	case "graph":
+		graph, err := runTriggerGraph(client)
+		if err != nil {
+			return err
+		}
+
+		for _, warning := range aid.GetRunTriggerGraphWarnings(graph) {
+			fmt.Fprintln(os.Stderr, warning)
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		return aid.RenderRunTriggerGraph(os.Stdout, graph, format)
+	}
+
+	return nil
+}
+
+// validateRunTriggerSource checks the source workspace is given either by ID, using --source-id, or by name, using --source
+func validateRunTriggerSource(cmd *cobra.Command) error {
+	id := helper.GetCmdFlagString(cmd, "source-id")
+	name := helper.GetCmdFlagString(cmd, "source")
+
+	if id == "" && name == "" {
+		return fmt.Errorf("either --source-id or --source must be defined")
+	}
+
+	if id != "" && name != "" {
+		return fmt.Errorf("--source-id and --source are mutually exclusive")
+	}
+
+	if name != "" && organization == "" {
+		return fmt.Errorf("--organization must be defined when using --source")
+	}
+
+	return nil
+}
+
+// runTriggerSourceLookup returns the source workspace ID given by --source-id, or resolves the --source name to its ID
+func runTriggerSourceLookup(client *tfe.Client, cmd *cobra.Command) (string, error) {
+	if id := helper.GetCmdFlagString(cmd, "source-id"); id != "" {
+		return id, nil
+	}
+
+	name := helper.GetCmdFlagString(cmd, "source")
+	workspace, err := workspaceRead(client, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to find workspace %s in organization %s\n%v", name, organization, err)
+	}
+
+	return workspace.ID, nil
+}
+
+// runTriggerGraph walks every workspace of the organization and builds the graph of their inbound run triggers
+func runTriggerGraph(client *tfe.Client) (*aid.RunTriggerGraph, error) {
+	workspaces, err := workspaceList(client, tfe.WorkspaceListOptions{}, aid.PaginationOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list workspaces of organization %s\n%v", organization, err)
+	}
+
+	names := make(map[string]string)
+	var triggers []*tfe.RunTrigger
+	for _, w := range workspaces.Items {
+		names[w.ID] = w.Name
+
+		list, err := runTriggerList(client, w.ID, "inbound", aid.PaginationOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list run triggers of workspace %s\n%v", w.Name, err)
+		}
+
+		triggers = append(triggers, list.Items...)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d run triggers found across %d workspaces\n", len(triggers), len(workspaces.Items))
+
+	return aid.BuildRunTriggerGraph(names, triggers), nil
+}
+
+// List the inbound or outbound run triggers of the workspace.
+func runTriggerList(client *tfe.Client, workspaceID string, runTriggerType string, pagination aid.PaginationOptions) (*tfe.RunTriggerList, error) {
+	list := &tfe.RunTriggerList{}
+	options := tfe.RunTriggerListOptions{RunTriggerType: &runTriggerType}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.RunTriggers.List(context.Background(), workspaceID, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a run trigger, applies on the source workspace will queue runs on the workspace.
+func runTriggerCreate(client *tfe.Client, workspaceID string, sourceID string) (*tfe.RunTrigger, error) {
+	return client.RunTriggers.Create(context.Background(), workspaceID, tfe.RunTriggerCreateOptions{
+		Sourceable: &tfe.Workspace{ID: sourceID},
+	})
+}
+
+// Read a run trigger by its ID.
+func runTriggerRead(client *tfe.Client, runTriggerID string) (*tfe.RunTrigger, error) {
+	return client.RunTriggers.Read(context.Background(), runTriggerID)
+}
+
+// Delete a run trigger by its ID.
+func runTriggerDelete(client *tfe.Client, runTriggerID string) error {
+	return client.RunTriggers.Delete(context.Background(), runTriggerID)
+}
@@ -57,6 +57,8 @@ func SSHKeyCmd() *cobra.Command {
 	usage = `The content of the SSH private key.`
 	cmd.Flags().String("value", "", usage)
 
+	aid.SetPaginationFlags(cmd)
+
 	return cmd
 }
 
@@ -111,7 +113,7 @@ func sshKeyPreRun(cmd *cobra.Command, args []string) error {
 func sshKeyRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	var sshKey *tfe.SSHKey
 	var err error
@@ -119,9 +121,9 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 	fArg := args[0]
 	switch fArg {
 	case "list":
-		list, err := sshKeyList(client, organization)
+		list, err := sshKeyList(client, organization, aid.GetPaginationOptions(cmd))
 		if err == nil {
-			aid.PrintSSHKeyList(list)
+			aid.PrintSSHKeyList(list, output)
 		} else {
 			return fmt.Errorf("no ssh key was found")
 		}
@@ -131,7 +133,7 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 		sshKey, err = sshKeyCreate(client, options)
 
 		if err == nil && sshKey.ID != "" {
-			fmt.Println(aid.ToJSON(sshKey))
+			aid.PrintOutput(output, sshKey)
 		}
 	case "read":
 		id, err := cmd.Flags().GetString("id")
@@ -141,7 +143,7 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 
 		sshKey, err := sshKeyRead(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(sshKey))
+			aid.PrintOutput(output, sshKey)
 		} else {
 			return fmt.Errorf("ssh key %s not found\n%v", id, err)
 		}
@@ -155,7 +157,7 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 		options := aid.GetSSHKeysUpdateOptions(cmd)
 		sshKey, err = sshKeyUpdate(client, id, options)
 		if err == nil && sshKey.ID != "" {
-			fmt.Println(aid.ToJSON(sshKey))
+			aid.PrintOutput(output, sshKey)
 		} else {
 			return fmt.Errorf("unable to update ssh key\n%v", err)
 		}
@@ -176,8 +178,21 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func sshKeyList(client *tfe.Client, organization string) (*tfe.SSHKeyList, error) {
-	return client.SSHKeys.List(context.Background(), organization, tfe.SSHKeyListOptions{})
+func sshKeyList(client *tfe.Client, organization string, pagination aid.PaginationOptions) (*tfe.SSHKeyList, error) {
+	list := &tfe.SSHKeyList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		page, err := client.SSHKeys.List(context.Background(), organization, tfe.SSHKeyListOptions{ListOptions: listOptions})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 // Create is used to create a new sshKey.
@@ -0,0 +1,411 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var stateVersionValidArgs = []string{"list", "read", "current", "download", "create", "outputs", "diff"}
+
+// StateVersionCmd command to manage the state versions of a workspace
+func StateVersionCmd() *cobra.Command {
+	man, err := helper.GetManual("state-version", stateVersionValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: stateVersionValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   stateVersionPreRun,
+		RunE:      stateVersionRun,
+	}
+
+	aid.SetStateVersionFlags(cmd)
+
+	return cmd
+}
+
+func stateVersionPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "state-version"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list", "current":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+	case "read":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state-version", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "download", "outputs":
+		if helper.GetCmdFlagString(cmd, "id") == "" {
+			if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+				return fmt.Errorf("--id must be defined, or the workspace to use its current state version\n%v", err)
+			}
+		}
+
+	case "create":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+	case "diff":
+		from := helper.GetCmdFlagString(cmd, "from")
+		to := helper.GetCmdFlagString(cmd, "to")
+
+		if from != "" || to != "" {
+			if from == "" || to == "" {
+				return fmt.Errorf("--from and --to must be defined together")
+			}
+
+			if helper.GetCmdFlagString(cmd, "workspace-id") != "" || helper.GetCmdFlagString(cmd, "workspace") != "" {
+				return fmt.Errorf("--from and --to can't be used along with the workspace")
+			}
+
+			break
+		}
+
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return fmt.Errorf("either --from and --to, or the workspace, must be defined\n%v", err)
+		}
+
+		versionsAgo, err := cmd.Flags().GetInt("versions-ago")
+		if err != nil {
+			return fmt.Errorf("unable to get flag versions-ago\n%v", err)
+		}
+
+		if versionsAgo < 1 {
+			return fmt.Errorf("--versions-ago must be greater than zero")
+		}
+	}
+
+	if fArg == "download" || fArg == "create" {
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state-version", fArg, "path"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func stateVersionRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		workspace, err := workspaceReadByID(client, workspaceID)
+		if err != nil {
+			return fmt.Errorf("unable to find workspace %s\n%v", workspaceID, err)
+		}
+
+		list, err := stateVersionList(client, workspace, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintStateVersionList(list, output)
+		} else {
+			return fmt.Errorf("no state version was found\n%v", err)
+		}
+
+	case "read":
+		id := helper.GetCmdFlagString(cmd, "id")
+
+		sv, err := stateVersionRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, sv)
+		} else {
+			return fmt.Errorf("state version %s not found\n%v", id, err)
+		}
+
+	case "current":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		sv, err := stateVersionCurrent(client, workspaceID)
+		if err == nil {
+			aid.PrintOutput(output, sv)
+		} else {
+			return fmt.Errorf("no current state version for workspace %s\n%v", workspaceID, err)
+		}
+
+	case "download":
+		sv, err := stateVersionLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		state, err := stateVersionDownload(client, sv.DownloadURL)
+		if err != nil {
+			return fmt.Errorf("unable to download state version %s\n%v", sv.ID, err)
+		}
+
+		// the state holds every secret of the workspace
+		path := helper.GetCmdFlagString(cmd, "path")
+		if err := ioutil.WriteFile(path, state, 0600); err != nil {
+			return fmt.Errorf("unable to write state version %s to %s\n%v", sv.ID, path, err)
+		}
+
+		fmt.Printf("state version %s (serial %d) downloaded successfully to %s\n", sv.ID, sv.Serial, path)
+
+	case "create":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		path := helper.GetCmdFlagString(cmd, "path")
+		state, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read file %s\n%v", path, err)
+		}
+
+		options, err := aid.GetStateVersionCreateOptions(cmd, state)
+		if err != nil {
+			return err
+		}
+
+		sv, err := stateVersionCreate(client, workspaceID, options)
+		if err == nil && sv.ID != "" {
+			aid.PrintOutput(output, sv)
+		} else {
+			return fmt.Errorf("unable to create state version, the workspace must be locked\n%v", err)
+		}
+
+	case "outputs":
+		sv, err := stateVersionLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		var outputs []*tfe.StateVersionOutput
+		for _, o := range sv.Outputs {
+			svo, err := stateVersionOutputRead(client, o.ID)
+			if err != nil {
+				return fmt.Errorf("unable to read output %s of state version %s\n%v", o.ID, sv.ID, err)
+			}
+			outputs = append(outputs, svo)
+		}
+
+		showSensitive, err := cmd.Flags().GetBool("show-sensitive")
+		if err != nil {
+			return fmt.Errorf("unable to get flag show-sensitive\n%v", err)
+		}
+
+		aid.PrintOutput(output, aid.MaskStateVersionOutputs(outputs, showSensitive))
+
+	case "diff":
+		from, to, err := stateVersionDiffLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		showSensitive, err := cmd.Flags().GetBool("show-sensitive")
+		if err != nil {
+			return fmt.Errorf("unable to get flag show-sensitive\n%v", err)
+		}
+
+		cmd.SilenceUsage = true
+
+		fromState, err := stateVersionDownloadState(client, from)
+		if err != nil {
+			return err
+		}
+
+		toState, err := stateVersionDownloadState(client, to)
+		if err != nil {
+			return err
+		}
+
+		diff, err := aid.DiffTerraformStates(fromState, toState, showSensitive)
+		if err != nil {
+			return err
+		}
+		diff.From = from.ID
+		diff.To = to.ID
+
+		// the report is meant to be read, unless an output format is asked for explicitly
+		if !cmd.Flags().Changed("output") {
+			return aid.RenderStateDiff(os.Stdout, diff)
+		}
+
+		aid.PrintOutput(output, diff)
+	}
+
+	return nil
+}
+
+// stateVersionLookup reads the state version given by --id, or the current state version of the workspace
+func stateVersionLookup(client *tfe.Client, cmd *cobra.Command) (*tfe.StateVersion, error) {
+	if id := helper.GetCmdFlagString(cmd, "id"); id != "" {
+		sv, err := stateVersionRead(client, id)
+		if err != nil {
+			return nil, fmt.Errorf("state version %s not found\n%v", id, err)
+		}
+		return sv, nil
+	}
+
+	workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+	if err != nil {
+		return nil, err
+	}
+
+	sv, err := stateVersionCurrent(client, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("no current state version for workspace %s\n%v", workspaceID, err)
+	}
+
+	return sv, nil
+}
+
+// stateVersionDiffLookup returns the state versions given by --from and --to,
+// or the current state version of the workspace and the one --versions-ago before it
+func stateVersionDiffLookup(client *tfe.Client, cmd *cobra.Command) (*tfe.StateVersion, *tfe.StateVersion, error) {
+	if from := helper.GetCmdFlagString(cmd, "from"); from != "" {
+		fromSV, err := stateVersionRead(client, from)
+		if err != nil {
+			return nil, nil, fmt.Errorf("state version %s not found\n%v", from, err)
+		}
+
+		to := helper.GetCmdFlagString(cmd, "to")
+		toSV, err := stateVersionRead(client, to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("state version %s not found\n%v", to, err)
+		}
+
+		return fromSV, toSV, nil
+	}
+
+	versionsAgo, err := cmd.Flags().GetInt("versions-ago")
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get flag versions-ago\n%v", err)
+	}
+
+	workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspace, err := workspaceReadByID(client, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to find workspace %s\n%v", workspaceID, err)
+	}
+
+	// state versions are listed from the newest to the oldest
+	list, err := stateVersionList(client, workspace, aid.PaginationOptions{Limit: versionsAgo + 1})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to list state versions of workspace %s\n%v", workspace.Name, err)
+	}
+
+	if len(list.Items) <= versionsAgo {
+		return nil, nil, fmt.Errorf("workspace %s has %d state version(s), unable to go %d version(s) back", workspace.Name, len(list.Items), versionsAgo)
+	}
+
+	return list.Items[versionsAgo], list.Items[0], nil
+}
+
+// stateVersionDownloadState downloads and decodes the state of the state version
+func stateVersionDownloadState(client *tfe.Client, sv *tfe.StateVersion) (*aid.TerraformState, error) {
+	b, err := stateVersionDownload(client, sv.DownloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download state version %s\n%v", sv.ID, err)
+	}
+
+	state, err := aid.ParseTerraformState(b)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse state version %s\n%v", sv.ID, err)
+	}
+
+	return state, nil
+}
+
+// List all the state versions of the workspace, the API filters them by organization and workspace names.
+func stateVersionList(client *tfe.Client, workspace *tfe.Workspace, pagination aid.PaginationOptions) (*tfe.StateVersionList, error) {
+	org := organization
+	if workspace.Organization != nil && workspace.Organization.Name != "" {
+		org = workspace.Organization.Name
+	}
+
+	list := &tfe.StateVersionList{}
+	options := tfe.StateVersionListOptions{Organization: &org, Workspace: &workspace.Name}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.StateVersions.List(context.Background(), options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a new state version for the given workspace.
+func stateVersionCreate(client *tfe.Client, workspaceID string, options tfe.StateVersionCreateOptions) (*tfe.StateVersion, error) {
+	return client.StateVersions.Create(context.Background(), workspaceID, options)
+}
+
+// Read a state version by its ID.
+func stateVersionRead(client *tfe.Client, svID string) (*tfe.StateVersion, error) {
+	return client.StateVersions.Read(context.Background(), svID)
+}
+
+// Current reads the latest available state from the given workspace.
+func stateVersionCurrent(client *tfe.Client, workspaceID string) (*tfe.StateVersion, error) {
+	return client.StateVersions.Current(context.Background(), workspaceID)
+}
+
+// Download retrieves the actual stored state of a state version.
+func stateVersionDownload(client *tfe.Client, url string) ([]byte, error) {
+	return client.StateVersions.Download(context.Background(), url)
+}
+
+// Read a state version output by its ID.
+func stateVersionOutputRead(client *tfe.Client, outputID string) (*tfe.StateVersionOutput, error) {
+	return client.StateVersionOutputs.Read(context.Background(), outputID)
+}
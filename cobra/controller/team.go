@@ -0,0 +1,257 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var teamValidArgs = []string{"list", "create", "read", "update", "delete"}
+
+// TeamCmd command to manage teams
+func TeamCmd() *cobra.Command {
+	man, err := helper.GetManual("team", teamValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: teamValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   teamPreRun,
+		RunE:      teamRun,
+	}
+
+	aid.SetTeamFlags(cmd)
+
+	return cmd
+}
+
+func teamPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "team"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team", fArg, "organization"); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team", fArg, "organization"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team", fArg, "name"); err != nil {
+			return err
+		}
+
+	case "read", "update", "delete":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team", fArg, "id"); err != nil {
+			return err
+		}
+	}
+
+	if fArg == "create" || fArg == "update" {
+		visibility, err := cmd.Flags().GetString("visibility")
+		if err != nil {
+			return fmt.Errorf("unable to get flag visibility\n%v", err)
+		}
+
+		if visibility != "" && visibility != "secret" && visibility != "organization" {
+			return fmt.Errorf("invalid value for --visibility: %s\nvalid values: secret or organization", visibility)
+		}
+	}
+
+	return nil
+}
+
+func teamRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		list, err := teamList(client, aid.GetTeamListOptions(cmd), aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintTeamList(list, output)
+		} else {
+			return fmt.Errorf("no team was found\n%v", err)
+		}
+
+	case "create":
+		options := aid.GetTeamCreateOptions(cmd)
+		team, err := teamCreate(client, options)
+		if err == nil && team.ID != "" {
+			aid.PrintOutput(output, team)
+		} else {
+			return fmt.Errorf("unable to create team\n%v", err)
+		}
+
+	case "read":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%v", err)
+		}
+
+		team, err := teamRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, team)
+		} else {
+			return fmt.Errorf("team %s not found\n%v", id, err)
+		}
+
+	case "update":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%v", err)
+		}
+
+		options := aid.GetTeamUpdateOptions(cmd)
+		team, err := teamUpdate(client, id, options)
+		if err == nil && team.ID != "" {
+			aid.PrintOutput(output, team)
+		} else {
+			return fmt.Errorf("unable to update team\n%v", err)
+		}
+
+	case "delete":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%v", err)
+		}
+
+		err = teamDelete(client, id)
+		if err == nil {
+			fmt.Printf("team %s deleted successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to delete team %s\n%v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// validateTeamLookup returns an error unless exactly one of idFlag or --team is given
+func validateTeamLookup(cmd *cobra.Command, idFlag string) error {
+	id := helper.GetCmdFlagString(cmd, idFlag)
+	name := helper.GetCmdFlagString(cmd, "team")
+
+	if id == "" && name == "" {
+		return fmt.Errorf("either --%s or --team must be defined", idFlag)
+	}
+
+	if id != "" && name != "" {
+		return fmt.Errorf("--%s and --team are mutually exclusive", idFlag)
+	}
+
+	if name != "" && organization == "" {
+		return fmt.Errorf("--organization must be defined when using --team")
+	}
+
+	return nil
+}
+
+// teamLookup returns the team ID given by idFlag, or resolves the --team name to its ID
+func teamLookup(client *tfe.Client, cmd *cobra.Command, idFlag string) (string, error) {
+	if id := helper.GetCmdFlagString(cmd, idFlag); id != "" {
+		return id, nil
+	}
+
+	name := helper.GetCmdFlagString(cmd, "team")
+	teamIDs, err := teamIDsByName(client)
+	if err != nil {
+		return "", err
+	}
+
+	id, found := teamIDs[name]
+	if !found {
+		return "", fmt.Errorf("unable to find team %s in organization %s", name, organization)
+	}
+
+	return id, nil
+}
+
+// teamIDsByName maps the name of every team of the organization to its ID
+func teamIDsByName(client *tfe.Client) (map[string]string, error) {
+	list, err := teamList(client, tfe.TeamListOptions{}, aid.PaginationOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list teams of organization %s\n%v", organization, err)
+	}
+
+	teamIDs := make(map[string]string)
+	for _, t := range list.Items {
+		teamIDs[t.Name] = t.ID
+	}
+
+	return teamIDs, nil
+}
+
+// List all the teams of the organization.
+func teamList(client *tfe.Client, options tfe.TeamListOptions, pagination aid.PaginationOptions) (*tfe.TeamList, error) {
+	list := &tfe.TeamList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.Teams.List(context.Background(), organization, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Create a new team with the given options.
+func teamCreate(client *tfe.Client, options tfe.TeamCreateOptions) (*tfe.Team, error) {
+	return client.Teams.Create(context.Background(), organization, options)
+}
+
+// Read a team by its ID.
+func teamRead(client *tfe.Client, teamID string) (*tfe.Team, error) {
+	return client.Teams.Read(context.Background(), teamID)
+}
+
+// Update a team by its ID.
+func teamUpdate(client *tfe.Client, teamID string, options tfe.TeamUpdateOptions) (*tfe.Team, error) {
+	return client.Teams.Update(context.Background(), teamID, options)
+}
+
+// Delete a team by its ID.
+func teamDelete(client *tfe.Client, teamID string) error {
+	return client.Teams.Delete(context.Background(), teamID)
+}
@@ -0,0 +1,353 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var teamAccessValidArgs = []string{"list", "add", "read", "update", "remove", "sync"}
+
+// TeamAccessCmd command to manage the access of teams to workspaces
+func TeamAccessCmd() *cobra.Command {
+	man, err := helper.GetManual("team-access", teamAccessValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: teamAccessValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   teamAccessPreRun,
+		RunE:      teamAccessRun,
+	}
+
+	aid.SetTeamAccessFlags(cmd)
+
+	return cmd
+}
+
+func teamAccessPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "team-access"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+	case "add":
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+			return err
+		}
+
+		if err := validateTeamLookup(cmd, "team-id"); err != nil {
+			return err
+		}
+
+		if err := aid.ValidateTeamAccessGrant(aid.GetTeamAccessGrant(cmd), true); err != nil {
+			return err
+		}
+
+	case "read", "update", "remove":
+		if err := validateTeamAccessLookup(cmd); err != nil {
+			return err
+		}
+
+		if fArg == "update" {
+			if err := aid.ValidateTeamAccessGrant(aid.GetTeamAccessGrant(cmd), false); err != nil {
+				return err
+			}
+		}
+
+	case "sync":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team-access", fArg, "file"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team-access", fArg, "organization"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func teamAccessRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		list, err := teamAccessList(client, workspaceID, aid.GetPaginationOptions(cmd))
+		if err == nil {
+			aid.PrintTeamAccessList(list, output)
+		} else {
+			return fmt.Errorf("no team access was found\n%v", err)
+		}
+
+	case "add":
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
+		teamID, err := teamLookup(client, cmd, "team-id")
+		if err != nil {
+			return err
+		}
+
+		access, err := teamAccessAdd(client, workspaceID, teamID, aid.GetTeamAccessAddOptions(aid.GetTeamAccessGrant(cmd)))
+		if err == nil && access.ID != "" {
+			aid.PrintOutput(output, access)
+		} else {
+			return fmt.Errorf("unable to add team access\n%v", err)
+		}
+
+	case "read":
+		id, err := teamAccessLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		access, err := teamAccessRead(client, id)
+		if err == nil {
+			aid.PrintOutput(output, access)
+		} else {
+			return fmt.Errorf("team access %s not found\n%v", id, err)
+		}
+
+	case "update":
+		id, err := teamAccessLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		access, err := teamAccessUpdate(client, id, aid.GetTeamAccessUpdateOptions(aid.GetTeamAccessGrant(cmd)))
+		if err == nil && access.ID != "" {
+			aid.PrintOutput(output, access)
+		} else {
+			return fmt.Errorf("unable to update team access\n%v", err)
+		}
+
+	case "remove":
+		id, err := teamAccessLookup(client, cmd)
+		if err != nil {
+			return err
+		}
+
+		err = teamAccessRemove(client, id)
+		if err == nil {
+			fmt.Printf("team access %s removed successfully\n", id)
+		} else {
+			return fmt.Errorf("unable to remove team access %s\n%v", id, err)
+		}
+
+	case "sync":
+		path, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return fmt.Errorf("unable to get flag file\n%v", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("unable to get flag dry-run\n%v", err)
+		}
+
+		file, err := aid.ReadTeamAccessSyncFile(path)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		return teamAccessSync(client, file, dryRun)
+	}
+
+	return nil
+}
+
+// validateTeamAccessLookup returns an error unless --id, or both the workspace and the team, are given
+func validateTeamAccessLookup(cmd *cobra.Command) error {
+	if helper.GetCmdFlagString(cmd, "id") != "" {
+		return nil
+	}
+
+	if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
+		return fmt.Errorf("--id must be defined, or the workspace and the team\n%v", err)
+	}
+
+	if err := validateTeamLookup(cmd, "team-id"); err != nil {
+		return fmt.Errorf("--id must be defined, or the workspace and the team\n%v", err)
+	}
+
+	return nil
+}
+
+// teamAccessLookup returns the --id flag, or finds the access of the team to the workspace
+func teamAccessLookup(client *tfe.Client, cmd *cobra.Command) (string, error) {
+	if id := helper.GetCmdFlagString(cmd, "id"); id != "" {
+		return id, nil
+	}
+
+	workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+	if err != nil {
+		return "", err
+	}
+
+	teamID, err := teamLookup(client, cmd, "team-id")
+	if err != nil {
+		return "", err
+	}
+
+	list, err := teamAccessList(client, workspaceID, aid.PaginationOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to list team access of workspace %s\n%v", workspaceID, err)
+	}
+
+	for _, a := range list.Items {
+		if a.Team != nil && a.Team.ID == teamID {
+			return a.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("team %s has no access to workspace %s", teamID, workspaceID)
+}
+
+// teamAccessSync reconciles the grants of every workspace of the file, teams not listed for a workspace lose their access
+func teamAccessSync(client *tfe.Client, file *aid.TeamAccessSyncFile, dryRun bool) error {
+	teamIDs, err := teamIDsByName(client)
+	if err != nil {
+		return err
+	}
+
+	var changes []aid.TeamAccessSyncChange
+	for _, w := range file.Workspaces {
+		workspace, err := workspaceRead(client, w.Name)
+		if err != nil {
+			return fmt.Errorf("unable to find workspace %s in organization %s\n%v", w.Name, organization, err)
+		}
+
+		list, err := teamAccessList(client, workspace.ID, aid.PaginationOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to list team access of workspace %s\n%v", w.Name, err)
+		}
+
+		c, err := aid.GetTeamAccessSyncChanges(w, workspace.ID, teamIDs, list.Items)
+		if err != nil {
+			return fmt.Errorf("unable to sync workspace %s\n%v", w.Name, err)
+		}
+
+		changes = append(changes, c...)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("team access is up to date")
+		return nil
+	}
+
+	for _, c := range changes {
+		fmt.Println(c)
+		if dryRun {
+			continue
+		}
+
+		switch c.Action {
+		case aid.TeamAccessSyncAdd:
+			_, err = teamAccessAdd(client, c.WorkspaceID, c.TeamID, aid.GetTeamAccessAddOptions(c.Grant))
+		case aid.TeamAccessSyncUpdate:
+			_, err = teamAccessUpdate(client, c.ID, aid.GetTeamAccessUpdateOptions(c.Grant))
+		case aid.TeamAccessSyncRemove:
+			err = teamAccessRemove(client, c.ID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to %s access of team %s on workspace %s\n%v", c.Action, c.Team, c.Workspace, err)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("%d change(s) to apply, run without --dry-run to apply them\n", len(changes))
+	} else {
+		fmt.Printf("%d change(s) applied successfully\n", len(changes))
+	}
+
+	return nil
+}
+
+// List all the team access of a workspace.
+func teamAccessList(client *tfe.Client, workspaceID string, pagination aid.PaginationOptions) (*tfe.TeamAccessList, error) {
+	list := &tfe.TeamAccessList{}
+	options := tfe.TeamAccessListOptions{WorkspaceID: &workspaceID}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.TeamAccess.List(context.Background(), options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
+}
+
+// Add team access to a workspace.
+func teamAccessAdd(client *tfe.Client, workspaceID string, teamID string, options tfe.TeamAccessAddOptions) (*tfe.TeamAccess, error) {
+	options.Team = &tfe.Team{ID: teamID}
+	options.Workspace = &tfe.Workspace{ID: workspaceID}
+	return client.TeamAccess.Add(context.Background(), options)
+}
+
+// Read a team access by its ID.
+func teamAccessRead(client *tfe.Client, teamAccessID string) (*tfe.TeamAccess, error) {
+	return client.TeamAccess.Read(context.Background(), teamAccessID)
+}
+
+// Update a team access by its ID.
+func teamAccessUpdate(client *tfe.Client, teamAccessID string, options tfe.TeamAccessUpdateOptions) (*tfe.TeamAccess, error) {
+	return client.TeamAccess.Update(context.Background(), teamAccessID, options)
+}
+
+// Remove team access from a workspace.
+func teamAccessRemove(client *tfe.Client, teamAccessID string) error {
+	return client.TeamAccess.Remove(context.Background(), teamAccessID)
+}
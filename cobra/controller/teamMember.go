@@ -0,0 +1,183 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/helper"
+)
+
+var teamMemberValidArgs = []string{"list", "add", "remove"}
+
+// TeamMemberCmd command to manage the members of a team
+func TeamMemberCmd() *cobra.Command {
+	man, err := helper.GetManual("team-member", teamMemberValidArgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cmd := &cobra.Command{
+		Use:       man.Use,
+		Short:     man.Short,
+		Long:      man.Long,
+		Example:   man.Example,
+		ValidArgs: teamMemberValidArgs,
+		Args:      cobra.OnlyValidArgs,
+		PreRunE:   teamMemberPreRun,
+		RunE:      teamMemberRun,
+	}
+
+	aid.SetTeamMemberFlags(cmd)
+
+	return cmd
+}
+
+func teamMemberPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "team-member"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	if err := helper.ValidateCmdArgAndFlag(cmd, args, "team-member", fArg, "team-id"); err != nil {
+		return err
+	}
+
+	switch fArg {
+	case "add", "remove":
+		usernames, userIDs := aid.GetTeamMemberUsers(cmd)
+		if len(usernames) == 0 && len(userIDs) == 0 {
+			return fmt.Errorf("either --username or --user-id must be defined")
+		}
+
+		if len(userIDs) > 0 && organization == "" {
+			return fmt.Errorf("--organization must be defined when using --user-id")
+		}
+	}
+
+	return nil
+}
+
+func teamMemberRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
+
+	teamID, err := cmd.Flags().GetString("team-id")
+	if err != nil {
+		return fmt.Errorf("unable to get flag team-id\n%v", err)
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		users, err := teamMemberList(client, teamID)
+		if err == nil {
+			aid.PrintOutput(output, users)
+		} else {
+			return fmt.Errorf("unable to list members of team %s\n%v", teamID, err)
+		}
+
+	case "add", "remove":
+		usernames, userIDs := aid.GetTeamMemberUsers(cmd)
+
+		if len(userIDs) > 0 {
+			names, err := teamMemberUsernames(client, userIDs)
+			if err != nil {
+				return err
+			}
+			usernames = append(usernames, names...)
+		}
+
+		if fArg == "add" {
+			err = teamMemberAdd(client, teamID, tfe.TeamMemberAddOptions{Usernames: usernames})
+		} else {
+			err = teamMemberRemove(client, teamID, tfe.TeamMemberRemoveOptions{Usernames: usernames})
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to %s members of team %s\n%v", fArg, teamID, err)
+		}
+
+		for _, name := range usernames {
+			if fArg == "add" {
+				fmt.Printf("user %s added to team %s successfully\n", name, teamID)
+			} else {
+				fmt.Printf("user %s removed from team %s successfully\n", name, teamID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// teamMemberUsernames resolves user IDs to usernames using the organization memberships
+func teamMemberUsernames(client *tfe.Client, userIDs []string) ([]string, error) {
+	usernames := make(map[string]string)
+
+	options := tfe.OrganizationMembershipListOptions{Include: "user"}
+	err := aid.Paginate(aid.PaginationOptions{}, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.OrganizationMemberships.List(context.Background(), organization, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, m := range page.Items {
+			if m.User != nil {
+				usernames[m.User.ID] = m.User.Username
+			}
+		}
+
+		return page.Pagination, len(page.Items), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list members of organization %s\n%v", organization, err)
+	}
+
+	var names []string
+	for _, id := range userIDs {
+		name, found := usernames[id]
+		if !found || name == "" {
+			return nil, fmt.Errorf("user %s is not a member of organization %s", id, organization)
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// List returns all users of a team.
+func teamMemberList(client *tfe.Client, teamID string) ([]*tfe.User, error) {
+	return client.TeamMembers.ListUsers(context.Background(), teamID)
+}
+
+// Add multiple users to a team.
+func teamMemberAdd(client *tfe.Client, teamID string, options tfe.TeamMemberAddOptions) error {
+	return client.TeamMembers.Add(context.Background(), teamID, options)
+}
+
+// Remove multiple users from a team.
+func teamMemberRemove(client *tfe.Client, teamID string, options tfe.TeamMemberRemoveOptions) error {
+	return client.TeamMembers.Remove(context.Background(), teamID, options)
+}
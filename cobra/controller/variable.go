@@ -70,7 +70,7 @@ func variablePreRun(cmd *cobra.Command, args []string) error {
 
 	switch args[0] {
 	case "list", "create", "delete-all":
-		if err := helper.ValidateCmdFlagString(cmd, "workspace-id"); err != nil {
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
 			return err
 		}
 
@@ -79,7 +79,7 @@ func variablePreRun(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		if err := helper.ValidateCmdFlagString(cmd, "workspace-id"); err != nil {
+		if err := validateWorkspaceLookup(cmd, "workspace-id"); err != nil {
 			return err
 		}
 
@@ -94,59 +94,77 @@ func variableRun(cmd *cobra.Command, args []string) error {
 	logrus.Tracef("start: variableRun")
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
-		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
 
-		list, err := variableList(client, workspaceID, tfe.VariableListOptions{})
+		list, err := variableList(client, workspaceID, tfe.VariableListOptions{}, aid.GetPaginationOptions(cmd))
 		if err == nil && len(list.Items) > 0 {
-			aid.PrintVariableList(list)
+			aid.PrintVariableList(list, output)
 		} else {
 			return fmt.Errorf("no variable was found")
 		}
 
 	case "create":
-		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
 		options := aid.GetVariableCreateOptions(cmd)
 
 		variable, err := variableCreate(client, workspaceID, options)
 		if err == nil && variable.ID != "" {
-			fmt.Println(aid.ToJSON(variable))
+			aid.PrintOutput(output, variable)
 		} else {
 			return fmt.Errorf("unable to create variable\n%v", err)
 		}
 
 	case "read":
-		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
 		id := helper.GetCmdFlagString(cmd, "id")
 
 		variable, err := variableRead(client, workspaceID, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(variable))
+			aid.PrintOutput(output, variable)
 		} else {
 			return fmt.Errorf("variable %s not found\n%v", id, err)
 		}
 
 	case "update":
-		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
 		id := helper.GetCmdFlagString(cmd, "id")
 		options := aid.GetVariableUpdateOptions(cmd)
 
 		variable, err := variableUpdate(client, workspaceID, id, options)
 		if err == nil && variable.ID != "" {
-			fmt.Println(aid.ToJSON(variable))
+			aid.PrintOutput(output, variable)
 		} else {
 			return fmt.Errorf("unable to update variable\n%v", err)
 		}
 
 	case "delete":
-		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+
 		id := helper.GetCmdFlagString(cmd, "id")
 
-		err := variableDelete(client, workspaceID, id)
+		err = variableDelete(client, workspaceID, id)
 		if err == nil {
 			fmt.Printf("variable %s deleted successfully\n", id)
 		} else {
@@ -154,8 +172,11 @@ func variableRun(cmd *cobra.Command, args []string) error {
 		}
 
 	case "delete-all":
-		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
-		list, err := variableList(client, workspaceID, tfe.VariableListOptions{})
+		workspaceID, err := workspaceLookup(client, cmd, "workspace-id")
+		if err != nil {
+			return err
+		}
+		list, err := variableList(client, workspaceID, tfe.VariableListOptions{}, aid.PaginationOptions{})
 		if err != nil {
 			return fmt.Errorf("no variable was found\n%v", err)
 		}
@@ -176,8 +197,22 @@ func variableRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func variableList(client *tfe.Client, workspaceID string, options tfe.VariableListOptions) (*tfe.VariableList, error) {
-	return client.Variables.List(context.Background(), workspaceID, options)
+func variableList(client *tfe.Client, workspaceID string, options tfe.VariableListOptions, pagination aid.PaginationOptions) (*tfe.VariableList, error) {
+	list := &tfe.VariableList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.Variables.List(context.Background(), workspaceID, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 func variableCreate(client *tfe.Client, workspaceID string, options tfe.VariableCreateOptions) (*tfe.Variable, error) {
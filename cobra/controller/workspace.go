@@ -100,13 +100,17 @@ func workspacePreRun(cmd *cobra.Command, args []string) error {
 	case "read-by-id",
 		"update-by-id",
 		"delete-by-id",
-		"remove-vcs-connection-by-id",
-		"lock",
+		"remove-vcs-connection-by-id":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "workspace", fArg, "id"); err != nil {
+			return err
+		}
+
+	case "lock",
 		"unlock",
 		"force-unlock",
 		"assign-ssh-key",
 		"unassign-ssh-key":
-		if err := helper.ValidateCmdArgAndFlag(cmd, args, "workspace", fArg, "id"); err != nil {
+		if err := validateWorkspaceLookup(cmd, "id"); err != nil {
 			return err
 		}
 
@@ -120,26 +124,26 @@ func workspacePreRun(cmd *cobra.Command, args []string) error {
 func workspaceRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client := aid.GetTFEClient(token, dao.GetAddress(profile))
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
 		options := aid.GetWorkspaceListOptions(cmd)
-		list, err := workspaceList(client, options)
+		list, err := workspaceList(client, options, aid.GetPaginationOptions(cmd))
 		if err == nil {
-			aid.PrintWorkspaceList(list)
+			aid.PrintWorkspaceList(list, output)
 		} else {
 			return fmt.Errorf("no workspace was found")
 		}
 	case "find-by-name":
-		list, err := workspaceList(client, tfe.WorkspaceListOptions{})
+		list, err := workspaceList(client, tfe.WorkspaceListOptions{}, aid.PaginationOptions{})
 		if err == nil {
 			w, err := workspaceFindByName(list, cmd)
 			if err != nil {
 				return err
 			}
-			fmt.Println(aid.ToJSON(w))
+			aid.PrintOutput(output, w)
 		} else {
 			return fmt.Errorf("no workspace was found")
 		}
@@ -148,7 +152,7 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		workspace, err := workspaceCreate(client, options)
 
 		if err == nil && workspace.ID != "" {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("unable to create workspace\n%v", err)
 		}
@@ -160,7 +164,7 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 
 		workspace, err := workspaceRead(client, name)
 		if err == nil {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("workspace %s not found\n%v", name, err)
 		}
@@ -172,7 +176,7 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 
 		workspace, err := workspaceReadByID(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("workspace %s not found\n%v", id, err)
 		}
@@ -185,7 +189,7 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		options := aid.GetWorkspaceUpdateOptions(cmd)
 		workspace, err := workspaceUpdate(client, name, options)
 		if err == nil && workspace.ID != "" {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("unable to update workspace\n%v", err)
 		}
@@ -198,7 +202,7 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		options := aid.GetWorkspaceUpdateOptions(cmd)
 		workspace, err := workspaceUpdateByID(client, id, options)
 		if err == nil && workspace.ID != "" {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("unable to update workspace\n%v", err)
 		}
@@ -234,7 +238,7 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 
 		workspace, err := workspaceRemoveVCSConnection(client, name)
 		if err == nil {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("unable to remove vcs connection\n%v", err)
 		}
@@ -246,14 +250,14 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 
 		workspace, err := workspaceRemoveVCSConnectionByID(client, id)
 		if err == nil {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		} else {
 			return fmt.Errorf("unable to remove vcs connection\n%v", err)
 		}
 	case "lock":
-		id, err := cmd.Flags().GetString("id")
+		id, err := workspaceLookup(client, cmd, "id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag id\n%v", err)
+			return err
 		}
 
 		workspace, err := workspaceLock(client, id)
@@ -262,12 +266,12 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		}
 
 		if workspace.Locked {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		}
 	case "unlock":
-		id, err := cmd.Flags().GetString("id")
+		id, err := workspaceLookup(client, cmd, "id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag id\n%v", err)
+			return err
 		}
 
 		workspace, err := workspaceUnlock(client, id)
@@ -276,12 +280,12 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		}
 
 		if !workspace.Locked {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		}
 	case "force-unlock":
-		id, err := cmd.Flags().GetString("id")
+		id, err := workspaceLookup(client, cmd, "id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag id\n%v", err)
+			return err
 		}
 
 		workspace, err := workspaceForceUnlock(client, id)
@@ -290,12 +294,12 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		}
 
 		if !workspace.Locked {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		}
 	case "assign-ssh-key":
-		id, err := cmd.Flags().GetString("id")
+		id, err := workspaceLookup(client, cmd, "id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag id\n%v", err)
+			return err
 		}
 
 		options := aid.GetWorkspaceAssignSSHKeyOptions(cmd)
@@ -305,10 +309,22 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		}
 
 		if workspace.ID != "" && workspace.SSHKey.ID != "" {
-			fmt.Println(aid.ToJSON(workspace))
+			aid.PrintOutput(output, workspace)
 		}
 	case "unassign-ssh-key":
-		fmt.Println("unassign-ssh-key")
+		id, err := workspaceLookup(client, cmd, "id")
+		if err != nil {
+			return err
+		}
+
+		workspace, err := workspaceUnassignSSHKey(client, id)
+		if err != nil {
+			return err
+		}
+
+		if workspace.ID != "" && workspace.SSHKey == nil {
+			aid.PrintOutput(output, workspace)
+		}
 	default:
 		return fmt.Errorf("unknown argument provided")
 	}
@@ -316,8 +332,58 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func workspaceList(client *tfe.Client, options tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
-	return client.Workspaces.List(context.Background(), organization, options)
+// validateWorkspaceLookup checks the workspace is given either by ID, using idFlag, or by name, using --workspace
+func validateWorkspaceLookup(cmd *cobra.Command, idFlag string) error {
+	id := helper.GetCmdFlagString(cmd, idFlag)
+	name := helper.GetCmdFlagString(cmd, "workspace")
+
+	if id == "" && name == "" {
+		return fmt.Errorf("either --%s or --workspace must be defined", idFlag)
+	}
+
+	if id != "" && name != "" {
+		return fmt.Errorf("--%s and --workspace are mutually exclusive", idFlag)
+	}
+
+	if name != "" && organization == "" {
+		return fmt.Errorf("--organization must be defined when using --workspace")
+	}
+
+	return nil
+}
+
+// workspaceLookup returns the workspace ID given by idFlag, or resolves the --workspace name to its ID
+func workspaceLookup(client *tfe.Client, cmd *cobra.Command, idFlag string) (string, error) {
+	if id := helper.GetCmdFlagString(cmd, idFlag); id != "" {
+		return id, nil
+	}
+
+	name := helper.GetCmdFlagString(cmd, "workspace")
+	workspace, err := workspaceRead(client, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to find workspace %s in organization %s\n%v", name, organization, err)
+	}
+
+	return workspace.ID, nil
+}
+
+// List all the workspaces of the organization, walking through every page unless told otherwise.
+func workspaceList(client *tfe.Client, options tfe.WorkspaceListOptions, pagination aid.PaginationOptions) (*tfe.WorkspaceList, error) {
+	list := &tfe.WorkspaceList{}
+	err := aid.Paginate(pagination, func(listOptions tfe.ListOptions) (*tfe.Pagination, int, error) {
+		options.ListOptions = listOptions
+		page, err := client.Workspaces.List(context.Background(), organization, options)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list.Pagination = page.Pagination
+		list.Items = append(list.Items, page.Items...)
+		return page.Pagination, len(page.Items), nil
+	})
+
+	list.Items = list.Items[:aid.GetLimit(pagination, len(list.Items))]
+	return list, err
 }
 
 func workspaceFindByName(list *tfe.WorkspaceList, cmd *cobra.Command) (*tfe.Workspace, error) {
@@ -17,7 +17,9 @@ limitations under the License.
 package dao
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -25,7 +27,9 @@ import (
 	"gitlab.aws.dev/devops-aws/tecli/cobra/model"
 )
 
-// GetCredentials read the current credentials file and return its model
+// GetCredentials read the current credentials file and return its model, profiles as they are stored.
+// The tokens of encrypted profiles stay in their ciphertext: the file can be listed, and saved back, without any passphrase.
+// Callers needing the tokens use GetCredentialProfile, or DecryptCredentialProfile on the profiles they change.
 func GetCredentials() (model.Credentials, error) {
 	var creds model.Credentials
 	err := viper.ReadInConfig()
@@ -38,61 +42,175 @@ func GetCredentials() (model.Credentials, error) {
 		return creds, fmt.Errorf("unable to unmarshall credentials\n%v", err)
 	}
 
-	return creds, err
+	return creds, nil
 }
 
-// GetCredentialProfile returns credentials of a profile
+// GetCredentialProfile returns credentials of a profile, decrypting its tokens if needed
 func GetCredentialProfile(name string) (model.CredentialProfile, error) {
-	credentials, err := GetCredentials()
+	profile, err := readCredentialProfile(name)
+	if err != nil {
+		return profile, err
+	}
 
+	return DecryptCredentialProfile(profile)
+}
+
+// readCredentialProfile returns the enabled profile as it's stored, an empty one if it doesn't exist
+func readCredentialProfile(name string) (model.CredentialProfile, error) {
+	credentials, err := GetCredentials()
 	if err != nil {
 		return (model.CredentialProfile{}), err
 	}
 
 	for _, profile := range credentials.Profiles {
 		if profile.Name == name && profile.Enabled {
-			return profile, err
+			return profile, nil
 		}
 	}
 
-	return (model.CredentialProfile{}), err
+	return (model.CredentialProfile{}), nil
 }
 
-// GetTeamToken return the team token from credentials file
+// GetTeamToken return the team token from TFC_TEAM_TOKEN, the credentials file or the Terraform CLI credentials, in this order
 func GetTeamToken(name string) string {
 	teamToken := viper.GetString("TEAM_TOKEN")
 	if teamToken != "" {
 		return teamToken
 	}
 
-	cp, err := GetCredentialProfile(name)
+	cp, err := lookupCredentialProfile(name, true)
 	if err != nil {
 		logrus.Fatalf("unable to read team token from credentials\n%v\n", err)
 	}
 
-	return cp.TeamToken
+	if cp.TeamToken != "" {
+		return cp.TeamToken
+	}
+
+	token, err := GetTerraformCLIToken(aid.GetHostname(GetAddress(name)))
+	if err != nil {
+		logrus.Fatalf("no team token found for profile %s, in the credentials file nor in the terraform credentials\n%v\n", name, err)
+	}
+
+	return token
 }
 
-// GetOrganizationToken return the organization token from credentials file
+// GetOrganizationToken return the organization token from TFC_ORGANIZATION_TOKEN, the credentials file or the Terraform CLI credentials, in this order
 func GetOrganizationToken(name string) string {
 	orgToken := viper.GetString("ORGANIZATION_TOKEN")
 	if orgToken != "" {
 		return orgToken
 	}
 
-	cp, err := GetCredentialProfile(name)
+	cp, err := lookupCredentialProfile(name, true)
 	if err != nil {
-		logrus.Fatalf("unable to read organization token from configurations\n%v\n", err)
+		logrus.Fatalf("unable to read organization token from credentials\n%v\n", err)
 	}
-	return cp.OrganizationToken
+
+	if cp.OrganizationToken != "" {
+		return cp.OrganizationToken
+	}
+
+	token, err := GetTerraformCLIToken(aid.GetHostname(GetAddress(name)))
+	if err != nil {
+		logrus.Fatalf("no organization token found for profile %s, in the credentials file nor in the terraform credentials\n%v\n", name, err)
+	}
+
+	return token
+}
+
+// GetAddress return the Terraform Enterprise address from credentials file
+func GetAddress(name string) string {
+	address := viper.GetString("ADDRESS")
+	if address != "" {
+		return address
+	}
+
+	// the address isn't encrypted, reading it doesn't need the passphrase
+	cp, err := lookupCredentialProfile(name, false)
+	if err != nil {
+		logrus.Fatalf("unable to read address from credentials\n%v\n", err)
+	}
+
+	// the address is optional, empty falls back to Terraform Cloud
+	return cp.Address
+}
+
+// lookupCredentialProfile returns the profile tokens and address are read from, an empty one if there's no credentials file or no such profile.
+// Other errors, an unreadable file or a wrong passphrase, are returned: falling back on other credentials would use another account, on another host maybe.
+func lookupCredentialProfile(name string, decrypt bool) (model.CredentialProfile, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) || os.IsNotExist(err) {
+			return (model.CredentialProfile{}), nil
+		}
+
+		return (model.CredentialProfile{}), fmt.Errorf("unable to read credentials\n%v", err)
+	}
+
+	if decrypt {
+		return GetCredentialProfile(name)
+	}
+
+	return readCredentialProfile(name)
 }
 
 // SaveCredentials saves the given credential onto the credentials file
 func SaveCredentials(credentials model.Credentials) error {
-	return aid.WriteInterfaceToFile(credentials, viper.ConfigFileUsed())
+	return SaveCredentialsV2(credentials, viper.ConfigFileUsed())
 }
 
 // SaveCredentialsV2 saves the given credential onto the credentials file
 func SaveCredentialsV2(credentials model.Credentials, path string) error {
+	credentials, err := encryptCredentials(credentials)
+	if err != nil {
+		return err
+	}
+
 	return aid.WriteInterfaceToFile(credentials, path)
 }
+
+// DecryptCredentialProfile restores the tokens of the profile if it uses the encrypted backend, asking for its passphrase
+func DecryptCredentialProfile(cp model.CredentialProfile) (model.CredentialProfile, error) {
+	if !aid.IsEncryptedCredentialProfile(cp) || cp.Ciphertext == "" {
+		return cp, nil
+	}
+
+	passphrase, err := aid.GetCredentialsPassphrase(cp.Name)
+	if err != nil {
+		return cp, err
+	}
+
+	return aid.DecryptCredentialProfile(cp, passphrase)
+}
+
+// encryptCredentials moves the tokens of the profiles using the encrypted backend into their ciphertext.
+// Profiles without plain tokens are left as they are, so saving doesn't ask for the passphrase of profiles that weren't changed.
+func encryptCredentials(credentials model.Credentials) (model.Credentials, error) {
+	// don't modify the caller's profiles
+	profiles := make([]model.CredentialProfile, len(credentials.Profiles))
+	copy(profiles, credentials.Profiles)
+	credentials.Profiles = profiles
+
+	for i, cp := range credentials.Profiles {
+		if !aid.IsEncryptedCredentialProfile(cp) || !hasPlainTokens(cp) {
+			continue
+		}
+
+		passphrase, err := aid.GetCredentialsPassphrase(cp.Name)
+		if err != nil {
+			return credentials, err
+		}
+
+		credentials.Profiles[i], err = aid.EncryptCredentialProfile(cp, passphrase)
+		if err != nil {
+			return credentials, err
+		}
+	}
+
+	return credentials, nil
+}
+
+func hasPlainTokens(cp model.CredentialProfile) bool {
+	return cp.UserToken != "" || cp.TeamToken != "" || cp.OrganizationToken != ""
+}
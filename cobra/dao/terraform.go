@@ -0,0 +1,53 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dao
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+)
+
+// GetTerraformCLIToken returns the token stored by the Terraform CLI for the given hostname,
+// looking at the credentials.tfrc.json file written by terraform login first, then at the CLI configuration file
+func GetTerraformCLIToken(hostname string) (string, error) {
+	credentialsPath, configPath := aid.GetTerraformCredentialsFiles()
+
+	if b, err := ioutil.ReadFile(credentialsPath); err == nil {
+		tokens, err := aid.ParseTerraformCredentialsJSON(b)
+		if err != nil {
+			return "", fmt.Errorf("unable to read %s\n%v", credentialsPath, err)
+		}
+
+		if token := tokens[hostname]; token != "" {
+			return token, nil
+		}
+	} else {
+		logrus.Debugf("unable to read terraform credentials file\n%v", err)
+	}
+
+	if b, err := ioutil.ReadFile(configPath); err == nil {
+		if token := aid.ParseTerraformRC(b)[hostname]; token != "" {
+			return token, nil
+		}
+	} else {
+		logrus.Debugf("unable to read terraform configuration file\n%v", err)
+	}
+
+	return "", fmt.Errorf("no terraform credentials found for %s", hostname)
+}
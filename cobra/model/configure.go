@@ -31,4 +31,7 @@ type CredentialProfile struct {
 	UserToken         string `yaml:"userToken"`
 	TeamToken         string `yaml:"teamToken"`
 	OrganizationToken string `yaml:"organizationToken"`
+	Address           string `yaml:"address,omitempty"`
+	Backend           string `yaml:"backend,omitempty"`
+	Ciphertext        string `yaml:"ciphertext,omitempty"`
 }
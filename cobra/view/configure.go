@@ -57,6 +57,7 @@ func AskAboutCredentialProfile(cmd *cobra.Command, cp model.CredentialProfile) m
 	cp.UserToken = aid.GetUserInputAsString(cmd, ">> User Token", cp.UserToken)
 	cp.TeamToken = aid.GetUserInputAsString(cmd, ">> Team Token", cp.TeamToken)
 	cp.OrganizationToken = aid.GetUserInputAsString(cmd, ">> Organization Token", cp.OrganizationToken)
+	cp.Address = aid.GetUserInputAsString(cmd, ">> Address", cp.Address)
 	cp.UpdatedAt = time.Now().String()
 
 	return cp
@@ -75,6 +76,13 @@ func UpdateCredentials(cmd *cobra.Command, name string) (model.Credentials, erro
 	for i, profile := range credentials.Profiles {
 		if profile.Name == name {
 			found = true
+
+			// the tokens are asked with their current values, encrypted ones included
+			profile, err = dao.DecryptCredentialProfile(profile)
+			if err != nil {
+				return model.Credentials{}, err
+			}
+
 			credentials.Profiles[i] = AskAboutCredentialProfile(cmd, profile)
 		}
 	}
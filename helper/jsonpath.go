@@ -0,0 +1,180 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EvaluateJSONPath evaluates a kubectl-like JSONPath template against the given JSON document.
+// Expressions are wrapped in curly braces, everything else is printed as is.
+// Supported expressions: {.field.nested}, {.list[0]}, {.list[*].field}, {[*].field} and quoted literals like {"\n"}.
+// Multiple results of a single expression are separated by a space.
+func EvaluateJSONPath(template string, document []byte) (string, error) {
+	var data interface{}
+	if err := json.Unmarshal(document, &data); err != nil {
+		return "", fmt.Errorf("unable to decode json\n%v", err)
+	}
+
+	var sb strings.Builder
+	rest := template
+	for rest != "" {
+		start := strings.Index(rest, "{")
+		if start == -1 {
+			sb.WriteString(rest)
+			break
+		}
+
+		sb.WriteString(rest[:start])
+		end := strings.Index(rest[start:], "}")
+		if end == -1 {
+			return "", fmt.Errorf("unclosed expression in jsonpath template: %s", template)
+		}
+
+		expression := strings.TrimSpace(rest[start+1 : start+end])
+		rest = rest[start+end+1:]
+
+		if strings.HasPrefix(expression, "\"") {
+			literal, err := strconv.Unquote(expression)
+			if err != nil {
+				return "", fmt.Errorf("invalid literal %s in jsonpath template\n%v", expression, err)
+			}
+			sb.WriteString(literal)
+			continue
+		}
+
+		results, err := lookupJSONPath(data, expression)
+		if err != nil {
+			return "", err
+		}
+
+		values := make([]string, 0, len(results))
+		for _, r := range results {
+			values = append(values, FormatJSONValue(r))
+		}
+		sb.WriteString(strings.Join(values, " "))
+	}
+
+	return sb.String(), nil
+}
+
+// ValidateJSONPath returns an error if the given template can't be parsed
+func ValidateJSONPath(template string) error {
+	_, err := EvaluateJSONPath(template, []byte("null"))
+	return err
+}
+
+// FormatJSONValue formats a decoded JSON value as plain text, nested values are printed as compact JSON
+func FormatJSONValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(b)
+	}
+}
+
+func lookupJSONPath(data interface{}, expression string) ([]interface{}, error) {
+	path := strings.TrimPrefix(expression, "$")
+	current := []interface{}{data}
+
+	for path != "" {
+		var next []interface{}
+
+		switch {
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+
+			key := path[:end]
+			path = path[end:]
+			if key == "" {
+				continue
+			}
+
+			for _, c := range current {
+				if m, ok := c.(map[string]interface{}); ok {
+					if v, found := m[key]; found {
+						next = append(next, v)
+					}
+				}
+			}
+
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed index in jsonpath expression: %s", expression)
+			}
+
+			index := strings.TrimSpace(path[1:end])
+			path = path[end+1:]
+
+			all := index == "*"
+			position := 0
+			if !all {
+				var err error
+				position, err = strconv.Atoi(index)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %s in jsonpath expression: %s", index, expression)
+				}
+			}
+
+			for _, c := range current {
+				list, ok := c.([]interface{})
+				if !ok {
+					continue
+				}
+
+				if all {
+					next = append(next, list...)
+					continue
+				}
+
+				i := position
+				if i < 0 {
+					i += len(list)
+				}
+
+				if i >= 0 && i < len(list) {
+					next = append(next, list[i])
+				}
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid jsonpath expression: %s", expression)
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/model"
+)
+
+func TestCredentialProfileEncryption(t *testing.T) {
+	cp := model.CredentialProfile{Name: "work", TeamToken: "team", OrganizationToken: "org", UserToken: "user"}
+
+	encrypted, err := aid.EncryptCredentialProfile(cp, "secret")
+	assert.Nil(t, err)
+	assert.True(t, aid.IsEncryptedCredentialProfile(encrypted))
+	assert.Empty(t, encrypted.TeamToken)
+	assert.NotContains(t, encrypted.Ciphertext, "team")
+
+	decrypted, err := aid.DecryptCredentialProfile(encrypted, "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, "team", decrypted.TeamToken)
+	assert.Equal(t, "org", decrypted.OrganizationToken)
+	assert.Equal(t, "user", decrypted.UserToken)
+	assert.Empty(t, decrypted.Ciphertext)
+
+	_, err = aid.DecryptCredentialProfile(encrypted, "wrong")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+}
+
+func TestEncryptUsesRandomSalt(t *testing.T) {
+	a, err := aid.Encrypt([]byte("token"), "secret")
+	assert.Nil(t, err)
+	b, err := aid.Encrypt([]byte("token"), "secret")
+	assert.Nil(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestWriteInterfaceToFilePermissions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "credentials.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte{}, 0777))
+	assert.Nil(t, os.Chmod(path, 0777))
+
+	assert.Nil(t, aid.WriteInterfaceToFile(model.Credentials{}, path))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+)
+
+func TestWriteSecretFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("previous token, world readable"), 0644))
+
+	assert.Nil(t, aid.WriteSecretFile(path, []byte("secret")))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(b))
+}
+
+func TestOpenSecretFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("previous token, world readable"), 0644))
+
+	f, err := aid.OpenSecretFile(path)
+	assert.Nil(t, err)
+
+	// restricted right away, the content is kept until the secret is written
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "previous token, world readable", string(b))
+
+	assert.Nil(t, aid.WriteSecret(f, []byte("secret")))
+
+	b, err = ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(b))
+
+	_, err = aid.OpenSecretFile(filepath.Join(dir, "missing", "token"))
+	assert.NotNil(t, err)
+}
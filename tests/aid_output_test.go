@@ -0,0 +1,116 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+var outputWorkspaces = []*tfe.Workspace{
+	{ID: "ws-1", Name: "network", TerraformVersion: "0.14.7", ExecutionMode: "remote"},
+	{ID: "ws-2", Name: "database", TerraformVersion: "0.13.6", ExecutionMode: "agent", Locked: true},
+}
+
+func TestRenderOutputJSONArray(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := aid.RenderOutput(buf, "json", outputWorkspaces)
+	assert.Nil(t, err)
+
+	var decoded []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded, 2)
+}
+
+func TestRenderOutputEmptyList(t *testing.T) {
+	buf := new(bytes.Buffer)
+	var empty []*tfe.Workspace
+	err := aid.RenderOutput(buf, "json", empty)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestRenderOutputYAML(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := aid.RenderOutput(buf, "yaml", outputWorkspaces[0])
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "Name: network")
+}
+
+func TestRenderOutputTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := aid.RenderOutput(buf, "table", outputWorkspaces)
+	assert.Nil(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 3)
+	assert.Contains(t, string(lines[0]), "TERRAFORM VERSION")
+	assert.Contains(t, string(lines[2]), "database")
+}
+
+func TestRenderOutputJSONPath(t *testing.T) {
+	tests := map[string]struct {
+		value    interface{}
+		template string
+		out      string
+	}{
+		"field":         {value: outputWorkspaces[0], template: "jsonpath={.Name}", out: "network\n"},
+		"all items":     {value: outputWorkspaces, template: "jsonpath={[*].ID}", out: "ws-1 ws-2\n"},
+		"index":         {value: outputWorkspaces, template: "jsonpath={[1].Locked}", out: "true\n"},
+		"with literals": {value: outputWorkspaces[1], template: `jsonpath=id={.ID}{"\t"}{.ExecutionMode}`, out: "id=ws-2\tagent\n"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := aid.RenderOutput(buf, tc.template, tc.value)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.out, buf.String())
+		})
+	}
+}
+
+func TestRenderOutputGoTemplate(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := aid.RenderOutput(buf, "go-template={{range .}}{{.Name}} {{end}}", outputWorkspaces)
+	assert.Nil(t, err)
+	assert.Equal(t, "network database \n", buf.String())
+}
+
+func TestOutputFlagValidation(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"unknown format":   {args: []string{"plan", "read", "--output", "xml"}, err: "unknown output format: xml"},
+		"invalid jsonpath": {args: []string{"plan", "read", "--output", "jsonpath={.ID"}, err: "unclosed expression"},
+		"invalid template": {args: []string{"plan", "read", "--output", "go-template={{.ID"}, err: "invalid go-template"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PlanCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
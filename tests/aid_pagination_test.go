@@ -0,0 +1,76 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+)
+
+// fakePages simulates an API with the given number of pages of 20 items each
+func fakePages(totalPages int, requested *[]tfe.ListOptions) aid.ListPage {
+	return func(options tfe.ListOptions) (*tfe.Pagination, int, error) {
+		*requested = append(*requested, options)
+
+		current := options.PageNumber
+		if current == 0 {
+			current = 1
+		}
+
+		next := current + 1
+		if next > totalPages {
+			next = 0
+		}
+
+		return &tfe.Pagination{CurrentPage: current, NextPage: next, TotalPages: totalPages}, 20, nil
+	}
+}
+
+func TestPaginateWalksAllPages(t *testing.T) {
+	var requested []tfe.ListOptions
+	err := aid.Paginate(aid.PaginationOptions{}, fakePages(3, &requested))
+	assert.Nil(t, err)
+	assert.Len(t, requested, 3)
+	assert.Equal(t, 3, requested[2].PageNumber)
+}
+
+func TestPaginateSinglePage(t *testing.T) {
+	var requested []tfe.ListOptions
+	err := aid.Paginate(aid.PaginationOptions{Page: 2, PageSize: 50}, fakePages(3, &requested))
+	assert.Nil(t, err)
+	assert.Len(t, requested, 1)
+	assert.Equal(t, tfe.ListOptions{PageNumber: 2, PageSize: 50}, requested[0])
+}
+
+func TestPaginateStopsAtLimit(t *testing.T) {
+	var requested []tfe.ListOptions
+	err := aid.Paginate(aid.PaginationOptions{Limit: 30, PageSize: 20}, fakePages(5, &requested))
+	assert.Nil(t, err)
+	assert.Len(t, requested, 2)
+	assert.Equal(t, 30, aid.GetLimit(aid.PaginationOptions{Limit: 30}, 40))
+	assert.Equal(t, 10, aid.GetLimit(aid.PaginationOptions{Limit: 30}, 10))
+}
+
+func TestPaginateShrinksPageSizeToLimit(t *testing.T) {
+	var requested []tfe.ListOptions
+	err := aid.Paginate(aid.PaginationOptions{Limit: 5}, fakePages(5, &requested))
+	assert.Nil(t, err)
+	assert.Len(t, requested, 1)
+	assert.Equal(t, 5, requested[0].PageSize)
+}
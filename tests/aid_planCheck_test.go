@@ -0,0 +1,174 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+const planCheckRules = `
+rules:
+  - name: protect-databases
+    description: databases are never deleted by a pipeline
+    actions: [delete]
+    resource_types: [aws_db_instance]
+    deny: true
+  - name: owner-tag
+    actions: [create]
+    resource_types: ["aws_*"]
+    require_tags: [owner, team]
+  - name: network-freeze
+    modules: [module.network]
+    deny: true
+`
+
+const planCheckPlan = `{"format_version": "1.1", "resource_changes": [
+	{"address": "aws_db_instance.main", "mode": "managed", "type": "aws_db_instance", "change": {"actions": ["delete", "create"], "after": {"tags": {"owner": "dba", "team": "data"}}}},
+	{"address": "aws_db_instance.legacy", "mode": "managed", "type": "aws_db_instance", "change": {"actions": ["delete"], "after": null}},
+	{"address": "aws_instance.web", "mode": "managed", "type": "aws_instance", "change": {"actions": ["create"], "after": {"tags": {"owner": "web"}, "tags_all": {"owner": "web", "team": "platform"}}}},
+	{"address": "aws_s3_bucket.logs", "mode": "managed", "type": "aws_s3_bucket", "change": {"actions": ["create"], "after": {"tags": null}}},
+	{"address": "google_storage_bucket.logs", "mode": "managed", "type": "google_storage_bucket", "change": {"actions": ["create"], "after": {}}},
+	{"address": "module.network.module.subnets.aws_subnet.a", "module_address": "module.network.module.subnets", "mode": "managed", "type": "aws_subnet", "change": {"actions": ["update"], "after": {}}},
+	{"address": "module.networking.aws_vpc.this", "module_address": "module.networking", "mode": "managed", "type": "aws_vpc", "change": {"actions": ["update"], "after": {}}}
+]}`
+
+func TestParsePlanRules(t *testing.T) {
+	rules, err := aid.ParsePlanRules([]byte(planCheckRules))
+	assert.Nil(t, err)
+	assert.Len(t, rules.Rules, 3)
+
+	tests := map[string]struct {
+		rules string
+		err   string
+	}{
+		"empty":          {rules: "rules: []", err: "doesn't define any rule"},
+		"unknown field":  {rules: "rules:\n  - name: a\n    deny: true\n    action: [delete]", err: "unable to decode rules file"},
+		"without name":   {rules: "rules:\n  - deny: true", err: "name of rule 1 must be defined"},
+		"duplicate name": {rules: "rules:\n  - name: a\n    deny: true\n  - name: a\n    deny: true", err: "rule a is defined more than once"},
+		"without effect": {rules: "rules:\n  - name: a", err: "must define either deny or require_tags"},
+		"both effects":   {rules: "rules:\n  - name: a\n    deny: true\n    require_tags: [owner]", err: "must define either deny or require_tags"},
+		"invalid action": {rules: "rules:\n  - name: a\n    deny: true\n    actions: [destroy]", err: "invalid action destroy in rule a"},
+		"invalid type":   {rules: "rules:\n  - name: a\n    deny: true\n    resource_types: [\"aws_[\"]", err: "invalid resource type pattern"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := aid.ParsePlanRules([]byte(tc.rules))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestCheckPlanRules(t *testing.T) {
+	rules, err := aid.ParsePlanRules([]byte(planCheckRules))
+	assert.Nil(t, err)
+
+	changes, err := aid.ParsePlanResourceChanges("plan-123", []byte(planCheckPlan))
+	assert.Nil(t, err)
+
+	var messages []string
+	for _, v := range aid.CheckPlanRules(rules, changes) {
+		messages = append(messages, v.Rule+": "+v.Message)
+	}
+
+	assert.Equal(t, []string{
+		"protect-databases: aws_db_instance.main must not be replaced",
+		"protect-databases: aws_db_instance.legacy must not be deleted",
+		"owner-tag: aws_s3_bucket.logs is missing tags: owner, team",
+		"network-freeze: module.network.module.subnets.aws_subnet.a must not be updated",
+	}, messages)
+}
+
+func TestParsePlanResourceChangesFormat(t *testing.T) {
+	tests := map[string]string{
+		"empty object":      `{}`,
+		"state file":        `{"format_version": "1.0", "terraform_version": "1.2.3", "values": {"root_module": {}}}`,
+		"no format version": `{"resource_changes": []}`,
+	}
+
+	for name, plan := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := aid.ParsePlanResourceChanges("plan.json", []byte(plan))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "plan.json isn't a JSON execution plan")
+		})
+	}
+
+	// a plan without changes has no resource changes, only planned values
+	changes, err := aid.ParsePlanResourceChanges("plan.json", []byte(`{"format_version": "1.0", "planned_values": {"root_module": {}}}`))
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestRenderPlanRuleViolations(t *testing.T) {
+	rules, _ := aid.ParsePlanRules([]byte(planCheckRules))
+	changes, _ := aid.ParsePlanResourceChanges("plan-123", []byte(planCheckPlan))
+
+	var out bytes.Buffer
+	assert.Nil(t, aid.RenderPlanRuleViolations(&out, "plan-123", aid.CheckPlanRules(rules, changes)))
+	assert.Contains(t, out.String(), "plan plan-123 has 4 rule violations:\n")
+	assert.Contains(t, out.String(), "\nprotect-databases: databases are never deleted by a pipeline\n"+
+		"  aws_db_instance.main must not be replaced\n"+
+		"  aws_db_instance.legacy must not be deleted\n")
+
+	out.Reset()
+	assert.Nil(t, aid.RenderPlanRuleViolations(&out, "plan-123", nil))
+	assert.Equal(t, "plan plan-123 complies with every rule\n", out.String())
+}
+
+func TestPlanCheckFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"without rules":    {args: []string{"plan", "check", "--id", "plan-123"}, err: "--rules must be defined"},
+		"without plan":     {args: []string{"plan", "check", "--rules", "rules.yaml"}, err: "either --id or --plan-file must be defined"},
+		"id and plan file": {args: []string{"plan", "check", "--rules", "rules.yaml", "--id", "plan-123", "--plan-file", "plan.json"}, err: "mutually exclusive"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PlanCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestPlanCheckPlanFileOffline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	rules := filepath.Join(dir, "rules.yaml")
+	plan := filepath.Join(dir, "plan.json")
+	assert.Nil(t, ioutil.WriteFile(rules, []byte(planCheckRules), 0600))
+	assert.Nil(t, ioutil.WriteFile(plan, []byte(planCheckPlan), 0600))
+
+	// no token is needed, the violations are found without reaching Terraform Cloud
+	_, err = executeCommand(t, controller.PlanCmd(), []string{"plan", "check", "--rules", rules, "--plan-file", plan})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "has 4 violations")
+}
@@ -0,0 +1,203 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+const planSummaryLogs = "\x02Terraform v0.14.7\n" +
+	"An execution plan has been generated and is shown below.\n\n" +
+	"  \x1b[1m# aws_instance.web\x1b[0m will be created\n" +
+	"\x1b[0m  \x1b[32m+\x1b[0m resource \"aws_instance\" \"web\" {\n" +
+	"  # module.vpc.aws_subnet.private[0] will be updated in-place\n" +
+	"  # module.vpc.aws_subnet.private[1] must be replaced\n" +
+	"  # module.vpc[\"eu.west\"].module.nat.aws_eip.this will be destroyed\n" +
+	"  # aws_instance.old (deposed object 5d0e) will be destroyed\n" +
+	"  # aws_instance.tainted is tainted, so must be replaced\n" +
+	"  # data.aws_ami.ubuntu will be read during apply\n" +
+	"  # (config refers to values not yet known)\n\n" +
+	"Plan: 3 to add, 1 to change, 4 to destroy.\n\x03"
+
+// planSummaryBlockLogs is a plan of Terraform 1.2, resources that changed outside of Terraform are listed before its actions
+const planSummaryBlockLogs = "Terraform v1.2.3\n" +
+	"Note: Objects have changed outside of Terraform\n\n" +
+	"  # aws_s3_bucket.drifted has been deleted\n" +
+	"  - resource \"aws_s3_bucket\" \"drifted\" {\n" +
+	"    }\n\n" +
+	"Terraform will perform the following actions:\n\n" +
+	"  # aws_instance.web will be replaced due to changes in replace_triggered_by\n" +
+	"-/+ resource \"aws_instance\" \"web\" {\n" +
+	"      ~ ami = \"ami-1\" -> \"ami-2\" # forces replacement\n" +
+	"        # (3 unchanged attributes hidden)\n" +
+	"    }\n\n" +
+	"  # aws_instance.blue[\"a b\"] will be swapped for a brand new one\n" +
+	"+/- resource \"aws_instance\" \"blue\" {\n" +
+	"    }\n\n" +
+	"  # aws_eip.old will be gone for good\n" +
+	"  - resource \"aws_eip\" \"old\" {\n" +
+	"    }\n\n" +
+	"  # aws_eip.new has moved to aws_eip.renamed\n" +
+	"    resource \"aws_eip\" \"renamed\" {\n" +
+	"    }\n\n" +
+	"  # data.aws_ami.ubuntu will be read during apply\n" +
+	"  # (config refers to values not yet known)\n" +
+	" <= data \"aws_ami\" \"ubuntu\" {\n" +
+	"    }\n\n" +
+	"  # aws_sqs_queue.unknown will be handled in some new way\n\n" +
+	"Plan: 3 to add, 0 to change, 4 to destroy.\n"
+
+// planSummaryHeredocLogs adds a file whose content holds comments, at a deeper indentation than the resource headers
+const planSummaryHeredocLogs = "Terraform will perform the following actions:\n\n" +
+	"  # local_file.bootstrap will be created\n" +
+	"  + resource \"local_file\" \"bootstrap\" {\n" +
+	"      + content  = <<-EOT\n" +
+	"            #!/bin/sh\n" +
+	"            # install the agent\n" +
+	"            curl -sSL https://example.com/agent | sh\n" +
+	"        EOT\n" +
+	"      + filename = \"bootstrap.sh\"\n" +
+	"    }\n\n" +
+	"Plan: 1 to add, 0 to change, 0 to destroy.\n"
+
+func TestParsePlanLogs(t *testing.T) {
+	summary, err := aid.ParsePlanLogs("plan-123", []byte(planSummaryLogs))
+	assert.Nil(t, err)
+
+	assert.Equal(t, aid.PlanSummaryFromLogs, summary.Source)
+	assert.Equal(t, 1, summary.Add)
+	assert.Equal(t, 1, summary.Change)
+	assert.Equal(t, 2, summary.Replace)
+	assert.Equal(t, 2, summary.Destroy)
+	assert.Equal(t, 4, summary.Destroys())
+
+	assert.Equal(t, []aid.PlanSummaryGroup{
+		{Module: "", Type: "aws_instance", Add: 1, Replace: 1, Destroy: 1},
+		{Module: "module.vpc", Type: "aws_subnet", Change: 1, Replace: 1},
+		{Module: "module.vpc[\"eu.west\"].module.nat", Type: "aws_eip", Destroy: 1},
+	}, summary.Groups)
+}
+
+func TestParsePlanLogsBlocks(t *testing.T) {
+	summary, err := aid.ParsePlanLogs("plan-123", []byte(planSummaryBlockLogs))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 0, summary.Add)
+	assert.Equal(t, 0, summary.Change)
+	assert.Equal(t, 3, summary.Replace)
+	assert.Equal(t, 1, summary.Destroy)
+	assert.Equal(t, []aid.PlanSummaryResource{
+		{Address: "aws_instance.web", Type: "aws_instance", Action: aid.PlanActionReplace},
+		{Address: "aws_instance.blue[\"a b\"]", Type: "aws_instance", Action: aid.PlanActionReplace},
+		{Address: "aws_eip.old", Type: "aws_eip", Action: aid.PlanActionDestroy},
+		// an unknown header without its block fails closed
+		{Address: "aws_sqs_queue.unknown", Type: "aws_sqs_queue", Action: aid.PlanActionReplace},
+	}, summary.Resources)
+}
+
+func TestParsePlanLogsHeredoc(t *testing.T) {
+	summary, err := aid.ParsePlanLogs("plan-123", []byte(planSummaryHeredocLogs))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, summary.Add)
+	assert.Equal(t, 0, summary.Destroys())
+	assert.Len(t, summary.Resources, 1)
+}
+
+func TestParsePlanLogsCounts(t *testing.T) {
+	tests := map[string]struct {
+		logs string
+		err  string
+	}{
+		"unfinished plan":   {logs: "Terraform v1.2.3\nInitializing plugins and modules...\n", err: "unable to find the counts of plan plan-123"},
+		"counts disagree":   {logs: strings.Replace(planSummaryHeredocLogs, "1 to add, 0 to change, 0 to destroy", "1 to add, 0 to change, 1 to destroy", 1), err: "but the plan counts 1 to add, 0 to change, 1 to destroy"},
+		"counts of nothing": {logs: "  # aws_instance.web will be created\n", err: "unable to find the counts"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := aid.ParsePlanLogs("plan-123", []byte(tc.logs))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestParsePlanJSON(t *testing.T) {
+	plan := `{"format_version": "1.0", "resource_changes": [
+		{"address": "aws_instance.web", "mode": "managed", "type": "aws_instance", "change": {"actions": ["create"]}},
+		{"address": "module.vpc.aws_subnet.a", "module_address": "module.vpc", "mode": "managed", "type": "aws_subnet", "change": {"actions": ["delete", "create"]}},
+		{"address": "module.vpc.aws_subnet.b", "module_address": "module.vpc", "mode": "managed", "type": "aws_subnet", "change": {"actions": ["create", "delete"]}},
+		{"address": "module.vpc.aws_vpc.this", "module_address": "module.vpc", "mode": "managed", "type": "aws_vpc", "change": {"actions": ["no-op"]}},
+		{"address": "aws_s3_bucket.logs", "mode": "managed", "type": "aws_s3_bucket", "change": {"actions": ["delete"]}},
+		{"address": "data.aws_ami.ubuntu", "mode": "data", "type": "aws_ami", "change": {"actions": ["read"]}}
+	]}`
+
+	summary, err := aid.ParsePlanJSON("plan-123", []byte(plan))
+	assert.Nil(t, err)
+	assert.Equal(t, aid.PlanSummaryFromJSON, summary.Source)
+	assert.Equal(t, 1, summary.Add)
+	assert.Equal(t, 0, summary.Change)
+	assert.Equal(t, 2, summary.Replace)
+	assert.Equal(t, 1, summary.Destroy)
+	assert.Len(t, summary.Resources, 4)
+	assert.Equal(t, aid.PlanSummaryGroup{Module: "module.vpc", Type: "aws_subnet", Replace: 2}, summary.Groups[2])
+
+	_, err = aid.ParsePlanJSON("plan-123", []byte("Terraform v0.14.7"))
+	assert.NotNil(t, err)
+}
+
+func TestRenderPlanSummary(t *testing.T) {
+	var out bytes.Buffer
+	summary, err := aid.ParsePlanLogs("plan-123", []byte(planSummaryLogs))
+	assert.Nil(t, err)
+	assert.Nil(t, aid.RenderPlanSummary(&out, summary))
+
+	assert.Contains(t, out.String(), "plan plan-123, summarized from its logs")
+	assert.Regexp(t, `\(root\) +aws_instance +1 +0 +1 +1`, out.String())
+	assert.Contains(t, out.String(), "  -/+ module.vpc.aws_subnet.private[1]\n")
+	assert.Contains(t, out.String(), "  -   aws_instance.old\n")
+	assert.Contains(t, out.String(), "Plan: 1 to add, 1 to change, 2 to replace, 2 to destroy.")
+
+	out.Reset()
+	summary, err = aid.ParsePlanLogs("plan-123", []byte("No changes. Infrastructure is up-to-date.\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, aid.RenderPlanSummary(&out, summary))
+	assert.Contains(t, out.String(), "No changes.")
+}
+
+func TestPlanSummaryFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"without id":           {args: []string{"plan", "summary"}, err: "--id must be defined"},
+		"negative max-destroy": {args: []string{"plan", "summary", "--id", "plan-123", "--max-destroy", "-1"}, err: "--max-destroy must be zero or greater"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PlanCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+)
+
+const stateBefore = `{
+  "version": 4,
+  "serial": 10,
+  "lineage": "abc",
+  "outputs": {
+    "vpc_id": {"value": "vpc-1", "type": "string"},
+    "db_password": {"value": "old", "type": "string", "sensitive": true},
+    "legacy": {"value": "x", "type": "string"}
+  },
+  "resources": [
+    {"mode": "managed", "type": "aws_vpc", "name": "main", "instances": [{"attributes": {"id": "vpc-1", "cidr_block": "10.0.0.0/16"}}]},
+    {"mode": "managed", "type": "aws_instance", "name": "web", "instances": [{"index_key": 0, "attributes": {"id": "i-1", "tags": {"env": "dev"}}}]},
+    {"mode": "data", "type": "aws_ami", "name": "ubuntu", "instances": [{"attributes": {"id": "ami-1"}}]}
+  ]
+}`
+
+const stateAfter = `{
+  "version": 4,
+  "serial": 12,
+  "lineage": "abc",
+  "outputs": {
+    "vpc_id": {"value": "vpc-1", "type": "string"},
+    "db_password": {"value": "new", "type": "string", "sensitive": true},
+    "subnet_ids": {"value": ["subnet-1"], "type": ["list", "string"]}
+  },
+  "resources": [
+    {"mode": "managed", "type": "aws_vpc", "name": "main", "instances": [{"attributes": {"id": "vpc-1", "cidr_block": "10.1.0.0/16"}}]},
+    {"mode": "managed", "type": "aws_instance", "name": "web", "instances": [{"index_key": 0, "attributes": {"id": "i-1", "tags": {"env": "dev"}}}, {"index_key": 1, "attributes": {"id": "i-2"}}]},
+    {"module": "module.net", "mode": "managed", "type": "aws_subnet", "name": "this", "instances": [{"index_key": "a", "attributes": {"id": "subnet-1"}}]}
+  ]
+}`
+
+func TestDiffTerraformStates(t *testing.T) {
+	before, err := aid.ParseTerraformState([]byte(stateBefore))
+	assert.Nil(t, err)
+	after, err := aid.ParseTerraformState([]byte(stateAfter))
+	assert.Nil(t, err)
+
+	diff, err := aid.DiffTerraformStates(before, after, false)
+	assert.Nil(t, err)
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, int64(10), diff.FromSerial)
+	assert.Equal(t, int64(12), diff.ToSerial)
+
+	assert.Equal(t, []aid.StateDiffChange{
+		{Address: "aws_instance.web[1]", Action: aid.StateDiffAdded},
+		{Address: "aws_vpc.main", Action: aid.StateDiffChanged, Attributes: []string{"cidr_block"}},
+		{Address: "data.aws_ami.ubuntu", Action: aid.StateDiffRemoved},
+		{Address: "module.net.aws_subnet.this[\"a\"]", Action: aid.StateDiffAdded},
+	}, diff.Resources)
+
+	assert.Len(t, diff.Outputs, 3)
+	assert.Equal(t, aid.StateDiffChange{Address: "db_password", Action: aid.StateDiffChanged, Before: aid.StateVersionSensitiveValue, After: aid.StateVersionSensitiveValue}, diff.Outputs[0])
+	assert.Equal(t, aid.StateDiffRemoved, diff.Outputs[1].Action)
+	assert.Equal(t, "subnet_ids", diff.Outputs[2].Address)
+
+	shown, err := aid.DiffTerraformStates(before, after, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "new", shown.Outputs[0].After)
+
+	var buf bytes.Buffer
+	assert.Nil(t, aid.RenderStateDiff(&buf, diff))
+	assert.Contains(t, buf.String(), "~ aws_vpc.main (cidr_block)")
+	assert.Contains(t, buf.String(), "- data.aws_ami.ubuntu")
+	assert.Contains(t, buf.String(), "+ subnet_ids = [\"subnet-1\"]")
+	assert.Contains(t, buf.String(), "Resources: 2 added, 1 changed, 1 removed.")
+}
+
+func TestDiffTerraformStatesNoChanges(t *testing.T) {
+	state, err := aid.ParseTerraformState([]byte(stateBefore))
+	assert.Nil(t, err)
+
+	diff, err := aid.DiffTerraformStates(state, state, false)
+	assert.Nil(t, err)
+	assert.False(t, diff.HasChanges())
+
+	var buf bytes.Buffer
+	assert.Nil(t, aid.RenderStateDiff(&buf, diff))
+	assert.Contains(t, buf.String(), "No changes.")
+
+	state.Version = 3
+	_, err = aid.DiffTerraformStates(state, state, false)
+	assert.NotNil(t, err)
+}
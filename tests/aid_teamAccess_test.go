@@ -0,0 +1,109 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+)
+
+func TestParseTeamAccessSyncFile(t *testing.T) {
+	file, err := aid.ParseTeamAccessSyncFile([]byte(`
+workspaces:
+  - name: network
+    teams:
+      - name: developers
+        access: plan
+      - name: auditors
+        access: custom
+        runs: read
+        state-versions: read-outputs
+`))
+	assert.Nil(t, err)
+	assert.Len(t, file.Workspaces, 1)
+	assert.Len(t, file.Workspaces[0].Teams, 2)
+	assert.Equal(t, "read-outputs", file.Workspaces[0].Teams[1].StateVersions)
+
+	tests := map[string]struct {
+		yaml string
+		err  string
+	}{
+		"unknown field":         {yaml: "workspaces:\n  - name: network\n    owner: ops\n", err: "unable to decode"},
+		"missing workspace":     {yaml: "workspaces:\n  - teams:\n      - name: ops\n        access: admin\n", err: "workspace name must be defined"},
+		"duplicate workspace":   {yaml: "workspaces:\n  - name: network\n  - name: network\n", err: "defined more than once"},
+		"duplicate team":        {yaml: "workspaces:\n  - name: network\n    teams:\n      - name: ops\n        access: admin\n      - name: ops\n        access: read\n", err: "team ops is defined more than once"},
+		"missing access":        {yaml: "workspaces:\n  - name: network\n    teams:\n      - name: ops\n", err: "--access must be defined"},
+		"invalid access":        {yaml: "workspaces:\n  - name: network\n    teams:\n      - name: ops\n        access: owner\n", err: "invalid value for access"},
+		"custom without custom": {yaml: "workspaces:\n  - name: network\n    teams:\n      - name: ops\n        access: read\n        runs: apply\n", err: "runs can only be set with custom access"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := aid.ParseTeamAccessSyncFile([]byte(tc.yaml))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestGetTeamAccessSyncChanges(t *testing.T) {
+	teamIDs := map[string]string{"developers": "team-dev", "ops": "team-ops", "auditors": "team-aud", "interns": "team-int"}
+	workspace := aid.TeamAccessSyncWorkspace{
+		Name: "network",
+		Teams: []aid.TeamAccessGrant{
+			{Name: "developers", Access: "plan"},
+			{Name: "ops", Access: "admin"},
+			{Name: "auditors", Access: "custom", Runs: "read"},
+		},
+	}
+
+	actual := []*tfe.TeamAccess{
+		{ID: "tws-dev", Access: tfe.AccessWrite, Team: &tfe.Team{ID: "team-dev"}},
+		{ID: "tws-ops", Access: tfe.AccessAdmin, Team: &tfe.Team{ID: "team-ops"}},
+		{ID: "tws-int", Access: tfe.AccessRead, Team: &tfe.Team{ID: "team-int"}},
+	}
+
+	changes, err := aid.GetTeamAccessSyncChanges(workspace, "ws-123", teamIDs, actual)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 3)
+
+	assert.Equal(t, aid.TeamAccessSyncUpdate, changes[0].Action)
+	assert.Equal(t, "tws-dev", changes[0].ID)
+	assert.Equal(t, aid.TeamAccessSyncAdd, changes[1].Action)
+	assert.Equal(t, "team-aud", changes[1].TeamID)
+	assert.Equal(t, "ws-123", changes[1].WorkspaceID)
+	assert.Equal(t, aid.TeamAccessSyncRemove, changes[2].Action)
+	assert.Equal(t, "interns", changes[2].Team)
+	assert.Equal(t, "tws-int", changes[2].ID)
+
+	workspace.Teams = append(workspace.Teams, aid.TeamAccessGrant{Name: "nobody", Access: "read"})
+	_, err = aid.GetTeamAccessSyncChanges(workspace, "ws-123", teamIDs, actual)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "team nobody not found")
+}
+
+func TestTeamAccessGrantMatches(t *testing.T) {
+	locking := true
+	access := &tfe.TeamAccess{Access: tfe.AccessCustom, Runs: tfe.RunsPermissionPlan, Variables: tfe.VariablesPermissionRead, WorkspaceLocking: true}
+
+	assert.True(t, aid.TeamAccessGrantMatches(aid.TeamAccessGrant{Access: "custom", Runs: "plan"}, access))
+	assert.True(t, aid.TeamAccessGrantMatches(aid.TeamAccessGrant{Access: "custom", WorkspaceLocking: &locking}, access))
+	assert.False(t, aid.TeamAccessGrantMatches(aid.TeamAccessGrant{Access: "custom", Variables: "write"}, access))
+	assert.False(t, aid.TeamAccessGrantMatches(aid.TeamAccessGrant{Access: "admin"}, access))
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+)
+
+func TestParseTerraformCredentialsJSON(t *testing.T) {
+	b := []byte(`{"credentials": {"app.terraform.io": {"token": "cloud-token"}, "tfe.example.com": {"token": "tfe-token"}}}`)
+
+	tokens, err := aid.ParseTerraformCredentialsJSON(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "cloud-token", tokens["app.terraform.io"])
+	assert.Equal(t, "tfe-token", tokens["tfe.example.com"])
+
+	_, err = aid.ParseTerraformCredentialsJSON([]byte("not json"))
+	assert.NotNil(t, err)
+}
+
+func TestParseTerraformRC(t *testing.T) {
+	b := []byte(`
+plugin_cache_dir = "$HOME/.terraform.d/plugin-cache"
+
+credentials "app.terraform.io" {
+  token = "cloud-token"
+}
+
+credentials "tfe.example.com" {
+  # managed by ops
+  token="tfe-token"
+}
+`)
+
+	tokens := aid.ParseTerraformRC(b)
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, "cloud-token", tokens["app.terraform.io"])
+	assert.Equal(t, "tfe-token", tokens["tfe.example.com"])
+}
+
+func TestGetHostname(t *testing.T) {
+	assert.Equal(t, "app.terraform.io", aid.GetHostname(""))
+	assert.Equal(t, "tfe.example.com", aid.GetHostname("https://tfe.example.com"))
+	assert.Equal(t, "tfe.example.com:8443", aid.GetHostname("https://tfe.example.com:8443/"))
+	assert.Equal(t, "tfe.example.com", aid.GetHostname("tfe.example.com"))
+}
@@ -18,6 +18,7 @@ package tests
 import (
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
 )
@@ -53,3 +54,28 @@ func TestWorkspaceCreateWithNoArgAndNoFlags(t *testing.T) {
 	assert.Equal(t, "this command requires one argument", err.Error())
 	assert.Contains(t, out, "")
 }
+
+func TestWorkspaceLookupFlags(t *testing.T) {
+	tests := map[string]struct {
+		cmd  *cobra.Command
+		args []string
+		err  string
+	}{
+		"lock without workspace":     {cmd: controller.WorkspaceCmd(), args: []string{"workspace", "lock"}, err: "either --id or --workspace must be defined"},
+		"lock with both":             {cmd: controller.WorkspaceCmd(), args: []string{"workspace", "lock", "--id", "ws-123", "--workspace", "network"}, err: "mutually exclusive"},
+		"unlock without org":         {cmd: controller.WorkspaceCmd(), args: []string{"workspace", "unlock", "--workspace", "network"}, err: "--organization must be defined"},
+		"force-unlock without any":   {cmd: controller.WorkspaceCmd(), args: []string{"workspace", "force-unlock"}, err: "either --id or --workspace must be defined"},
+		"unassign-ssh-key with both": {cmd: controller.WorkspaceCmd(), args: []string{"workspace", "unassign-ssh-key", "--id", "ws-123", "--workspace", "network"}, err: "mutually exclusive"},
+		"run list without org":       {cmd: controller.RunCmd(), args: []string{"run", "list", "--workspace", "network"}, err: "--organization must be defined"},
+		"variable list with both":    {cmd: controller.VariableCmd(), args: []string{"variable", "list", "--workspace-id", "ws-123", "--workspace", "network"}, err: "mutually exclusive"},
+		"cv create without any":      {cmd: controller.ConfigurationVersionCmd(), args: []string{"configuration-version", "create"}, err: "either --workspace-id or --workspace must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, tc.cmd, tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestAgentPoolCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":               {args: []string{"agent-pool"}, err: "this command requires one argument"},
+		"create without name": {args: []string{"agent-pool", "create", "--organization", "my-org"}, err: "--name must be defined"},
+		"read without id":     {args: []string{"agent-pool", "read"}, err: "--id must be defined"},
+		"update without name": {args: []string{"agent-pool", "update", "--id", "apool-123"}, err: "--name must be defined"},
+		"delete without id":   {args: []string{"agent-pool", "delete"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.AgentPoolCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestAgentTokenCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                 {args: []string{"agent-token"}, err: "this command requires one argument"},
+		"list without pool":     {args: []string{"agent-token", "list"}, err: "either --agent-pool-id or --agent-pool must be defined"},
+		"generate without pool": {args: []string{"agent-token", "generate"}, err: "either --agent-pool-id or --agent-pool must be defined"},
+		"both pools":            {args: []string{"agent-token", "generate", "--agent-pool-id", "apool-123", "--agent-pool", "on-premises"}, err: "mutually exclusive"},
+		"read without id":       {args: []string{"agent-token", "read"}, err: "--id must be defined"},
+		"delete without id":     {args: []string{"agent-token", "delete"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.AgentTokenCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
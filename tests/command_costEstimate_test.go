@@ -0,0 +1,98 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestCostEstimateCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                 {args: []string{"cost-estimate"}, err: "this command requires one argument"},
+		"read without id":       {args: []string{"cost-estimate", "read"}, err: "--id must be defined"},
+		"logs without id":       {args: []string{"cost-estimate", "logs"}, err: "--id must be defined"},
+		"report invalid format": {args: []string{"cost-estimate", "report", "--organization", "my-org", "--format", "xlsx"}, err: "invalid value for --format"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.CostEstimateCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func costEstimateReport(t *testing.T) *aid.CostEstimateReport {
+	estimates := []struct {
+		workspace *tfe.Workspace
+		ce        *tfe.CostEstimate
+	}{
+		{&tfe.Workspace{ID: "ws-net", Name: "network"}, &tfe.CostEstimate{ID: "ce-1", Status: tfe.CostEstimateFinished, PriorMonthlyCost: "100.5", ProposedMonthlyCost: "120.75", DeltaMonthlyCost: "20.25"}},
+		{&tfe.Workspace{ID: "ws-db", Name: "database"}, &tfe.CostEstimate{ID: "ce-2", Status: tfe.CostEstimateFinished, PriorMonthlyCost: "50", ProposedMonthlyCost: "40", DeltaMonthlyCost: "-10"}},
+		{&tfe.Workspace{ID: "ws-app", Name: "app"}, &tfe.CostEstimate{ID: "ce-3", Status: tfe.CostEstimateErrored}},
+	}
+
+	var rows []aid.CostEstimateReportRow
+	for i, e := range estimates {
+		row, err := aid.NewCostEstimateReportRow(e.workspace, "run-"+string(rune('a'+i)), e.ce)
+		assert.Nil(t, err)
+		rows = append(rows, row)
+	}
+
+	return aid.NewCostEstimateReport("my-org", rows)
+}
+
+func TestCostEstimateReport(t *testing.T) {
+	report := costEstimateReport(t)
+
+	assert.Equal(t, aid.CostEstimateReportTotal{Workspaces: 2, PriorMonthlyCost: 150.5, ProposedMonthlyCost: 160.75, DeltaMonthlyCost: 10.25}, report.Total)
+
+	_, err := aid.NewCostEstimateReportRow(&tfe.Workspace{}, "run-a", &tfe.CostEstimate{ID: "ce-1", Status: tfe.CostEstimateFinished, PriorMonthlyCost: "n/a"})
+	assert.NotNil(t, err)
+
+	// workspaces whose cost estimate can't be read stay in the report, out of the total
+	rows := append(report.Workspaces, aid.CostEstimateReportRow{Workspace: "broken", WorkspaceID: "ws-broken", RunID: "run-d", Status: aid.CostEstimateReportErrored})
+	assert.Equal(t, report.Total, aid.NewCostEstimateReport("my-org", rows).Total)
+}
+
+func TestRenderCostEstimateReport(t *testing.T) {
+	report := costEstimateReport(t)
+
+	var csv bytes.Buffer
+	assert.Nil(t, aid.RenderCostEstimateReport(&csv, report, aid.CostEstimateReportCSV))
+	assert.Equal(t, "workspace,workspace_id,run_id,cost_estimate_id,status,prior_monthly_cost,proposed_monthly_cost,delta_monthly_cost\n"+
+		"network,ws-net,run-a,ce-1,finished,100.50,120.75,20.25\n"+
+		"database,ws-db,run-b,ce-2,finished,50.00,40.00,-10.00\n"+
+		"app,ws-app,run-c,ce-3,errored,,,\n"+
+		"TOTAL,,,,,150.50,160.75,10.25\n", csv.String())
+
+	var table bytes.Buffer
+	assert.Nil(t, aid.RenderCostEstimateReport(&table, report, aid.CostEstimateReportTable))
+	assert.Contains(t, table.String(), "WORKSPACE")
+	assert.Regexp(t, `network +finished +100.50 +120.75 +\+20.25`, table.String())
+	assert.Regexp(t, `app +errored +- +- +-`, table.String())
+	assert.Regexp(t, `TOTAL \(2 workspaces\) +150.50 +160.75 +\+10.25`, table.String())
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestDeployCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"unexpected arg":    {args: []string{"deploy", "foo"}, err: "unknown command"},
+		"no workspace":      {args: []string{"deploy"}, err: "either --workspace-id or --workspace must be defined"},
+		"both workspaces":   {args: []string{"deploy", "--workspace-id", "ws-123", "--workspace", "network"}, err: "mutually exclusive"},
+		"name without org":  {args: []string{"deploy", "--workspace", "network"}, err: "--organization must be defined"},
+		"missing directory": {args: []string{"deploy", "--workspace-id", "ws-123", "--path", "does-not-exist"}, err: "--path must be an existing directory"},
+		"wrong flag":        {args: []string{"deploy", "--foo"}, err: "unknown flag"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.DeployCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestNotificationCmdFlags(t *testing.T) {
+	create := []string{"notification", "create", "--name", "ci"}
+
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                  {args: []string{"notification"}, err: "this command requires one argument"},
+		"list without workspace": {args: []string{"notification", "list"}, err: "either --workspace-id or --workspace must be defined"},
+		"create without name":    {args: []string{"notification", "create", "--workspace-id", "ws-123"}, err: "--name must be defined"},
+		"create without type":    {args: append(create, "--workspace-id", "ws-123"), err: "--destination-type must be defined"},
+		"create invalid type":    {args: append(create, "--workspace-id", "ws-123", "--destination-type", "teams"), err: "invalid value for --destination-type"},
+		"slack without url":      {args: append(create, "--workspace-id", "ws-123", "--destination-type", "slack"), err: "--url must be defined for slack destinations"},
+		"invalid trigger":        {args: append(create, "--workspace-id", "ws-123", "--destination-type", "email", "--trigger", "run:finished"), err: "invalid value for --trigger"},
+		"create without target":  {args: append(create, "--destination-type", "email"), err: "either --workspace-id or --workspace must be defined"},
+		"search and file":        {args: append(create, "--destination-type", "email", "--search", "prod-", "--workspaces-file", "ws.txt"), err: "mutually exclusive"},
+		"search and workspace":   {args: append(create, "--destination-type", "email", "--search", "prod-", "--workspace-id", "ws-123"), err: "can't be used along with --workspace-id or --workspace"},
+		"search without org":     {args: append(create, "--destination-type", "email", "--search", "prod-"), err: "--organization must be defined when using --search or --workspaces-file"},
+		"verify without id":      {args: []string{"notification", "verify"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.NotificationCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestNotificationUpdateOptions(t *testing.T) {
+	cmd := controller.NotificationCmd()
+	assert.Nil(t, cmd.ParseFlags([]string{"--id", "nc-123", "--name", "deploys"}))
+
+	// the lists that aren't given must not be sent, they would be cleared
+	options := aid.GetNotificationUpdateOptions(cmd)
+	assert.Equal(t, "deploys", *options.Name)
+	assert.Nil(t, options.Triggers)
+	assert.Nil(t, options.EmailAddresses)
+	assert.Nil(t, options.EmailUsers)
+	assert.Nil(t, options.URL)
+
+	cmd = controller.NotificationCmd()
+	assert.Nil(t, cmd.ParseFlags([]string{"--id", "nc-123", "--trigger", "run:errored", "--email-user-id", "user-1"}))
+
+	options = aid.GetNotificationUpdateOptions(cmd)
+	assert.Equal(t, []string{"run:errored"}, options.Triggers)
+	assert.Equal(t, []*tfe.User{{ID: "user-1"}}, options.EmailUsers)
+	assert.Nil(t, options.EmailAddresses)
+}
+
+func TestParseWorkspaceNames(t *testing.T) {
+	b := []byte("# production workspaces\nnetwork\n\n  database  \nnetwork\ncompute # owned by ops\n")
+	assert.Equal(t, []string{"network", "database", "compute"}, aid.ParseWorkspaceNames(b))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestOrganizationCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                {args: []string{"organization"}, err: "this command requires one argument"},
+		"wrong arg":            {args: []string{"organization", "foo"}, err: "invalid argument"},
+		"create without name":  {args: []string{"organization", "create", "--email", "admin@example.com"}, err: "--name must be defined"},
+		"create without email": {args: []string{"organization", "create", "--name", "my-org"}, err: "--email must be defined"},
+		"read without org":     {args: []string{"organization", "read"}, err: "--organization must be defined"},
+		"capacity without org": {args: []string{"organization", "capacity"}, err: "--organization must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.OrganizationCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -16,9 +16,15 @@ limitations under the License.
 package tests
 
 import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
 	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
 )
 
@@ -49,3 +55,107 @@ func TestPlanDelete(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Contains(t, out, "")
 }
+
+func TestPlanLogWriter(t *testing.T) {
+	chunks := []string{"\x02Terraform v0.14.7\n\x1b[0m\x1b[1m", "Plan:\x1b[0m 1 to add\x1b", "[0m\n\x03"}
+
+	tests := map[string]struct {
+		noColor bool
+		out     string
+	}{
+		"with colors":    {noColor: false, out: "Terraform v0.14.7\n\x1b[0m\x1b[1mPlan:\x1b[0m 1 to add\x1b[0m\n"},
+		"without colors": {noColor: true, out: "Terraform v0.14.7\nPlan: 1 to add\n"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := aid.NewLogWriter(buf, tc.noColor)
+			for _, c := range chunks {
+				n, err := w.Write([]byte(c))
+				assert.Nil(t, err)
+				assert.Equal(t, len(c), n)
+			}
+			assert.Equal(t, tc.out, buf.String())
+		})
+	}
+}
+
+func TestPlanCreateFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"no workspace":      {args: []string{"plan", "create", "--speculative"}, err: "either --workspace-id or --workspace must be defined"},
+		"not speculative":   {args: []string{"plan", "create", "--workspace-id", "ws-123"}, err: "--speculative must be defined"},
+		"missing directory": {args: []string{"plan", "create", "--workspace-id", "ws-123", "--speculative", "--path", "does-not-exist"}, err: "--path must be an existing directory"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PlanCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestPlanExitCode(t *testing.T) {
+	assert.Equal(t, 0, aid.GetPlanExitCode(&tfe.Plan{HasChanges: false}))
+	assert.Equal(t, aid.PlanExitChanges, aid.GetPlanExitCode(&tfe.Plan{HasChanges: true}))
+}
+
+func TestPlanExportFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"export without id":     {args: []string{"plan", "export", "--path", "mocks.tar.gz"}, err: "--id must be defined"},
+		"export without path":   {args: []string{"plan", "export", "--id", "plan-123"}, err: "--path must be defined"},
+		"export invalid format": {args: []string{"plan", "export", "--id", "plan-123", "--path", "mocks.tar.gz", "--format", "json"}, err: "invalid value for --format"},
+		"json without id":       {args: []string{"plan", "json"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PlanCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestPlanJSONOutput(t *testing.T) {
+	archive := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"format_version":"0.1","resource_changes":[]}`))
+	}))
+	defer archive.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/v2/plans/plan-123/json-output":
+			http.Redirect(w, r, archive.URL+"/plan.json", http.StatusTemporaryRedirect)
+		case "/api/v2/plans/plan-pending/json-output":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api := aid.NewPlanAPI("secret", server.URL)
+
+	b, err := api.JSONOutput(context.Background(), "plan-123")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"format_version":"0.1","resource_changes":[]}`, string(b))
+
+	_, err = api.JSONOutput(context.Background(), "plan-pending")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "it may not be finished yet")
+
+	_, err = api.JSONOutput(context.Background(), "plan-missing")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+}
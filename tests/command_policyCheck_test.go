@@ -0,0 +1,66 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestPolicyCheckCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":               {args: []string{"policy-check"}, err: "this command requires one argument"},
+		"list without run":    {args: []string{"policy-check", "list"}, err: "--run-id must be defined"},
+		"logs without id":     {args: []string{"policy-check", "logs"}, err: "--id must be defined"},
+		"override without id": {args: []string{"policy-check", "override"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PolicyCheckCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestGetPolicyCheckSummary(t *testing.T) {
+	checks := []*tfe.PolicyCheck{
+		{
+			ID:      "polchk-1",
+			Scope:   tfe.PolicyScopeOrganization,
+			Status:  tfe.PolicySoftFailed,
+			Result:  &tfe.PolicyResult{Passed: 3, SoftFailed: 1, AdvisoryFailed: 2},
+			Actions: &tfe.PolicyActions{IsOverridable: true},
+		},
+		{ID: "polchk-2", Status: tfe.PolicyPending},
+	}
+
+	summary := aid.GetPolicyCheckSummary(checks)
+	assert.Len(t, summary, 2)
+	assert.Equal(t, aid.PolicyCheckSummary{ID: "polchk-1", Scope: tfe.PolicyScopeOrganization, Status: tfe.PolicySoftFailed, Passed: 3, AdvisoryFailed: 2, SoftFailed: 1, Overridable: true}, summary[0])
+	assert.Equal(t, tfe.PolicyPending, summary[1].Status)
+
+	assert.True(t, aid.IsPolicyCheckOverridable(checks[0]))
+	assert.False(t, aid.IsPolicyCheckOverridable(checks[1]))
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestPolicyCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                {args: []string{"policy"}, err: "this command requires one argument"},
+		"wrong arg":            {args: []string{"policy", "foo"}, err: "invalid argument"},
+		"list without org":     {args: []string{"policy", "list"}, err: "--organization must be defined"},
+		"create without name":  {args: []string{"policy", "create", "--organization", "my-org", "--enforcement-level", "advisory"}, err: "--name must be defined"},
+		"create without level": {args: []string{"policy", "create", "--organization", "my-org", "--name", "require-tags"}, err: "--enforcement-level must be defined"},
+		"create invalid level": {args: []string{"policy", "create", "--organization", "my-org", "--name", "require-tags", "--enforcement-level", "strict"}, err: "invalid value for --enforcement-level"},
+		"read without id":      {args: []string{"policy", "read"}, err: "--id must be defined"},
+		"upload without file":  {args: []string{"policy", "upload", "--id", "pol-123"}, err: "--file must be defined"},
+		"create missing file":  {args: []string{"policy", "create", "--organization", "my-org", "--name", "require-tags", "--enforcement-level", "advisory", "--file", "missing.sentinel"}, err: "unable to read file missing.sentinel"},
+		"upload missing file":  {args: []string{"policy", "upload", "--id", "pol-123", "--file", "missing.sentinel"}, err: "unable to read file missing.sentinel"},
+		"download without id":  {args: []string{"policy", "download"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PolicyCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestPolicySetCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"list without org":            {args: []string{"policy-set", "list"}, err: "--organization must be defined"},
+		"create without name":         {args: []string{"policy-set", "create", "--organization", "my-org"}, err: "--name must be defined"},
+		"update without id":           {args: []string{"policy-set", "update"}, err: "--id must be defined"},
+		"add-policies without policy": {args: []string{"policy-set", "add-policies", "--id", "polset-123"}, err: "--policy-id must be defined"},
+		"add-workspaces without ws":   {args: []string{"policy-set", "add-workspaces", "--id", "polset-123"}, err: "either --workspace-id or --workspace must be defined"},
+		"workspace name without org":  {args: []string{"policy-set", "remove-workspaces", "--id", "polset-123", "--workspace", "network"}, err: "--organization must be defined when using --workspace"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.PolicySetCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestRegistryModuleCmdFlags(t *testing.T) {
+	module := []string{"--organization", "my-org", "--name", "vpc", "--provider", "aws"}
+
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                    {args: []string{"registry-module"}, err: "this command requires one argument"},
+		"create without provider":  {args: []string{"registry-module", "create", "--organization", "my-org", "--name", "vpc"}, err: "--provider must be defined"},
+		"create vcs without token": {args: []string{"registry-module", "create", "--organization", "my-org", "--identifier", "org/terraform-aws-vpc"}, err: "--oauth-token-id must be defined"},
+		"version without version":  {args: append([]string{"registry-module", "create-version"}, module...), err: "--version must be defined"},
+		"upload without path":      {args: append([]string{"registry-module", "upload", "--version", "1.0.0"}, module...), err: "--path must be defined"},
+		"upload missing path":      {args: append([]string{"registry-module", "upload", "--version", "1.0.0", "--path", "does-not-exist"}, module...), err: "module directory does-not-exist not found"},
+		"read without name":        {args: []string{"registry-module", "read", "--organization", "my-org"}, err: "--name must be defined"},
+		"delete version alone":     {args: []string{"registry-module", "delete", "--organization", "my-org", "--name", "vpc", "--version", "1.0.0"}, err: "--provider must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.RegistryModuleCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestValidateRegistryModuleDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	err = aid.ValidateRegistryModuleDir(dir)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "doesn't contain any .tf file")
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte("variable \"name\" {}\n"), 0644))
+	err = aid.ValidateRegistryModuleDir(dir)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "doesn't contain a README")
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# vpc\n"), 0644))
+	assert.Nil(t, aid.ValidateRegistryModuleDir(dir))
+}
+
+func TestRegistryModuleAPI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte("variable \"name\" {}\n"), 0644))
+
+	var uploaded int64
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/organizations/my-org/registry-modules":
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+			w.Write([]byte(`{"data":[{"id":"mod-1","attributes":{"name":"vpc","provider":"aws","status":"setup_complete"}}],"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":21}}}`))
+
+		case r.Method == "POST" && r.URL.Path == "/api/v2/registry-modules/my-org/vpc/aws/versions":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"data":{"type":"registry-module-versions","attributes":{"version":"1.2.0"}}}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"id":"modver-1","attributes":{"version":"1.2.0","status":"pending","source":"tfe-api"},"links":{"upload":"` + server.URL + `/upload/modver-1"}}}`))
+
+		case r.Method == "PUT" && r.URL.Path == "/upload/modver-1":
+			assert.Empty(t, r.Header.Get("Authorization"))
+			uploaded = r.ContentLength
+
+		case r.URL.Path == "/api/v2/registry-modules/my-org/missing/aws/versions":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"status":"404","title":"not found"}]}`))
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	api := aid.NewRegistryModuleAPI("secret", server.URL)
+
+	list, err := api.List(context.Background(), "my-org", tfe.ListOptions{PageNumber: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 21, list.TotalCount)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "vpc", list.Items[0].Name)
+	assert.Equal(t, tfe.RegistryModuleStatusSetupComplete, list.Items[0].Status)
+
+	mv, err := api.CreateVersion(context.Background(), "my-org", "vpc", "aws", "1.2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "modver-1", mv.ID)
+	assert.Equal(t, server.URL+"/upload/modver-1", mv.UploadURL)
+
+	assert.Nil(t, api.Upload(context.Background(), mv.UploadURL, dir))
+	assert.True(t, uploaded > 0)
+
+	_, err = api.CreateVersion(context.Background(), "my-org", "missing", "aws", "1.0.0")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+}
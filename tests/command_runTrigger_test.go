@@ -0,0 +1,136 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestRunTriggerCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                  {args: []string{"run-trigger"}, err: "this command requires one argument"},
+		"list without workspace": {args: []string{"run-trigger", "list"}, err: "either --workspace-id or --workspace must be defined"},
+		"list invalid type":      {args: []string{"run-trigger", "list", "--workspace-id", "ws-123", "--type", "both"}, err: "invalid value for --type"},
+		"create without source":  {args: []string{"run-trigger", "create", "--workspace-id", "ws-123"}, err: "either --source-id or --source must be defined"},
+		"create both sources":    {args: []string{"run-trigger", "create", "--workspace-id", "ws-123", "--source-id", "ws-456", "--source", "network"}, err: "mutually exclusive"},
+		"read without id":        {args: []string{"run-trigger", "read"}, err: "--id must be defined"},
+		"delete without id":      {args: []string{"run-trigger", "delete"}, err: "--id must be defined"},
+		"graph invalid format":   {args: []string{"run-trigger", "graph", "--organization", "my-org", "--format", "svg"}, err: "invalid value for --format"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.RunTriggerCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func runTrigger(id string, source string, sourceName string, target string, targetName string) *tfe.RunTrigger {
+	return &tfe.RunTrigger{
+		ID:             id,
+		SourceableName: sourceName,
+		WorkspaceName:  targetName,
+		Sourceable:     &tfe.Workspace{ID: source},
+		Workspace:      &tfe.Workspace{ID: target},
+	}
+}
+
+func TestBuildRunTriggerGraph(t *testing.T) {
+	workspaces := map[string]string{"ws-net": "network", "ws-db": "database", "ws-app": "app", "ws-a": "a", "ws-b": "b"}
+	triggers := []*tfe.RunTrigger{
+		runTrigger("rt-1", "ws-net", "network", "ws-db", "database"),
+		runTrigger("rt-2", "ws-db", "database", "ws-app", "app"),
+		runTrigger("rt-3", "ws-a", "a", "ws-b", "b"),
+		runTrigger("rt-4", "ws-b", "b", "ws-a", "a"),
+		runTrigger("rt-5", "ws-gone", "legacy", "ws-app", "app"),
+	}
+
+	graph := aid.BuildRunTriggerGraph(workspaces, triggers)
+
+	assert.Len(t, graph.Nodes, 6)
+	assert.Len(t, graph.Edges, 5)
+	assert.Equal(t, [][]string{{"ws-a", "ws-b"}}, graph.Cycles)
+
+	for _, n := range graph.Nodes {
+		assert.Equal(t, n.ID == "ws-net" || n.ID == "ws-gone", n.Orphan, n.ID)
+		assert.Equal(t, n.ID == "ws-gone", n.Missing, n.ID)
+		assert.Equal(t, n.ID == "ws-a" || n.ID == "ws-b", n.InCycle, n.ID)
+	}
+
+	for _, e := range graph.Edges {
+		assert.Equal(t, e.ID == "rt-3" || e.ID == "rt-4", e.InCycle, e.ID)
+	}
+
+	assert.Equal(t, []string{
+		"cycle: workspaces a, b trigger each other",
+		"orphan source: legacy (ws-gone) triggers runs, but no workspace triggers it",
+		"orphan source: network (ws-net) triggers runs, but no workspace triggers it",
+		"missing workspace: legacy (ws-gone) isn't a workspace of the organization",
+	}, aid.GetRunTriggerGraphWarnings(graph))
+}
+
+func TestBuildRunTriggerGraphSelfLoop(t *testing.T) {
+	graph := aid.BuildRunTriggerGraph(map[string]string{"ws-a": "a"}, []*tfe.RunTrigger{runTrigger("rt-1", "ws-a", "a", "ws-a", "a")})
+	assert.Equal(t, [][]string{{"ws-a"}}, graph.Cycles)
+	assert.True(t, graph.Edges[0].InCycle)
+}
+
+func TestRenderRunTriggerGraph(t *testing.T) {
+	workspaces := map[string]string{"ws-net": "network", "ws-db": "database"}
+	triggers := []*tfe.RunTrigger{
+		runTrigger("rt-1", "ws-net", "network", "ws-db", "database"),
+		runTrigger("rt-2", "ws-db", "database", "ws-net", "network"),
+		runTrigger("rt-3", "ws-gone", "legacy", "ws-db", "database"),
+	}
+	graph := aid.BuildRunTriggerGraph(workspaces, triggers)
+
+	var dot bytes.Buffer
+	assert.Nil(t, aid.RenderRunTriggerGraph(&dot, graph, aid.RunTriggerGraphDOT))
+	assert.Contains(t, dot.String(), "digraph run_triggers {")
+	assert.Contains(t, dot.String(), "\"ws-net\" -> \"ws-db\" [color=red];")
+	assert.Contains(t, dot.String(), "\"ws-gone\" -> \"ws-db\";")
+	assert.Contains(t, dot.String(), "\"ws-gone\" [label=\"legacy\", style=dashed, tooltip=\"orphan source\", fontcolor=gray")
+	assert.Contains(t, dot.String(), "\"ws-net\" [label=\"network\", color=red];")
+
+	var mermaid bytes.Buffer
+	assert.Nil(t, aid.RenderRunTriggerGraph(&mermaid, graph, aid.RunTriggerGraphMermaid))
+	assert.Equal(t, "graph LR\n"+
+		"  n0[\"database\"]\n"+
+		"  n1[\"legacy\"]\n"+
+		"  n2[\"network\"]\n"+
+		"  n0 --> n2\n"+
+		"  n1 --> n0\n"+
+		"  n2 --> n0\n"+
+		"  classDef cycle stroke:#d00,stroke-width:2px\n"+
+		"  class n0,n2 cycle\n"+
+		"  classDef orphan stroke-dasharray:5 5\n"+
+		"  class n1 orphan\n"+
+		"  classDef missing color:#999\n"+
+		"  class n1 missing\n", mermaid.String())
+
+	assert.NotNil(t, aid.RenderRunTriggerGraph(&mermaid, graph, "svg"))
+}
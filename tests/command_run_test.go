@@ -17,8 +17,11 @@ package tests
 
 import (
 	"testing"
+	"time"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
 	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
 )
 
@@ -49,3 +52,89 @@ func TestRunDelete(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Contains(t, out, "")
 }
+
+func TestRunStatusExitCode(t *testing.T) {
+	tests := map[tfe.RunStatus]struct {
+		finished bool
+		code     int
+	}{
+		tfe.RunPending:            {finished: false, code: 0},
+		tfe.RunPlanning:           {finished: false, code: 0},
+		tfe.RunApplied:            {finished: true, code: 0},
+		tfe.RunPlannedAndFinished: {finished: true, code: 0},
+		tfe.RunErrored:            {finished: true, code: aid.RunExitErrored},
+		tfe.RunCanceled:           {finished: true, code: aid.RunExitCanceled},
+		tfe.RunDiscarded:          {finished: true, code: aid.RunExitDiscarded},
+		tfe.RunPolicySoftFailed:   {finished: true, code: aid.RunExitPolicySoftFailed},
+	}
+
+	for status, tc := range tests {
+		t.Run(string(status), func(t *testing.T) {
+			assert.Equal(t, tc.finished, aid.IsRunFinished(status))
+			assert.Equal(t, tc.code, aid.GetRunExitCode(status))
+		})
+	}
+}
+
+func TestRunWaitExitCode(t *testing.T) {
+	tests := map[string]struct {
+		run  *tfe.Run
+		over bool
+		code int
+	}{
+		"planning":                   {run: &tfe.Run{Status: tfe.RunPlanning, Actions: &tfe.RunActions{}}, over: false, code: 0},
+		"planned confirmable":        {run: &tfe.Run{Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true}}, over: true, code: aid.RunExitNeedsConfirmation},
+		"cost estimated confirmable": {run: &tfe.Run{Status: tfe.RunCostEstimated, Actions: &tfe.RunActions{IsConfirmable: true}}, over: true, code: aid.RunExitNeedsConfirmation},
+		"policy checked confirmable": {run: &tfe.Run{Status: tfe.RunPolicyChecked, Actions: &tfe.RunActions{IsConfirmable: true}}, over: true, code: aid.RunExitNeedsConfirmation},
+		"planned without actions":    {run: &tfe.Run{Status: tfe.RunPlanned}, over: false, code: 0},
+		"applied":                    {run: &tfe.Run{Status: tfe.RunApplied, Actions: &tfe.RunActions{}}, over: true, code: 0},
+		"errored":                    {run: &tfe.Run{Status: tfe.RunErrored, Actions: &tfe.RunActions{}}, over: true, code: aid.RunExitErrored},
+		"policy override":            {run: &tfe.Run{Status: tfe.RunPolicyOverride, Actions: &tfe.RunActions{}}, over: true, code: aid.RunExitPolicySoftFailed},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.over, aid.IsRunWaitOver(tc.run))
+			assert.Equal(t, tc.code, aid.GetRunWaitExitCode(tc.run))
+		})
+	}
+}
+
+func TestRunPolicyOverridable(t *testing.T) {
+	tests := map[tfe.RunStatus]bool{
+		tfe.RunPolicyOverride:   true,
+		tfe.RunPolicySoftFailed: true,
+		tfe.RunPolicyChecked:    false,
+		tfe.RunPolicyChecking:   false,
+		tfe.RunErrored:          false,
+		tfe.RunApplied:          false,
+	}
+
+	for status, overridable := range tests {
+		t.Run(string(status), func(t *testing.T) {
+			assert.Equal(t, overridable, aid.IsRunPolicyOverridable(status))
+		})
+	}
+}
+
+func TestRunWaitOptionsDeadline(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+
+	options := aid.RunWaitOptions{Wait: true}.WithDeadline(start)
+	assert.True(t, options.Deadline.IsZero())
+
+	// the deadline is shared by every step, the time already spent counts
+	options = aid.RunWaitOptions{Wait: true, Timeout: 30 * time.Minute}.WithDeadline(start)
+	assert.Equal(t, start.Add(30*time.Minute), options.Deadline)
+
+	ctx, cancel := options.Context()
+	defer cancel()
+	assert.NotNil(t, ctx.Err())
+}
+
+func TestRunCreateOverrideSoftFailRequiresWait(t *testing.T) {
+	args := []string{"run", "create", "--workspace-id", "ws-123", "--override-soft-fail"}
+	_, err := executeCommand(t, controller.RunCmd(), args)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "--override-soft-fail requires --wait")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestStateVersionCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                  {args: []string{"state-version"}, err: "this command requires one argument"},
+		"list without workspace": {args: []string{"state-version", "list"}, err: "either --workspace-id or --workspace must be defined"},
+		"read without id":        {args: []string{"state-version", "read"}, err: "--id must be defined"},
+		"download without path":  {args: []string{"state-version", "download", "--id", "sv-123"}, err: "--path must be defined"},
+		"outputs without id":     {args: []string{"state-version", "outputs"}, err: "--id must be defined, or the workspace"},
+		"create without path":    {args: []string{"state-version", "create", "--workspace-id", "ws-123"}, err: "--path must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.StateVersionCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestGetStateVersionCreateOptions(t *testing.T) {
+	state := []byte(`{"version": 4, "terraform_version": "0.14.4", "serial": 42, "lineage": "5f6a-lineage", "outputs": {}, "resources": []}`)
+
+	options, err := aid.GetStateVersionCreateOptions(controller.StateVersionCmd(), state)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), *options.Serial)
+	assert.Equal(t, "5f6a-lineage", *options.Lineage)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum(state)), *options.MD5)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(state), *options.State)
+	assert.Nil(t, options.Force)
+
+	_, err = aid.GetStateVersionCreateOptions(controller.StateVersionCmd(), []byte(`{"foo": "bar"}`))
+	assert.NotNil(t, err)
+}
+
+func TestMaskStateVersionOutputs(t *testing.T) {
+	outputs := []*tfe.StateVersionOutput{
+		{Name: "vpc_id", Value: "vpc-123"},
+		{Name: "db_password", Value: "hunter2", Sensitive: true},
+	}
+
+	masked := aid.MaskStateVersionOutputs(outputs, false)
+	assert.Equal(t, "vpc-123", masked[0].Value)
+	assert.Equal(t, aid.StateVersionSensitiveValue, masked[1].Value)
+	assert.Equal(t, "hunter2", outputs[1].Value)
+
+	shown := aid.MaskStateVersionOutputs(outputs, true)
+	assert.Equal(t, "hunter2", shown[1].Value)
+}
+
+func TestStateVersionDiffFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"nothing":            {args: []string{"state-version", "diff"}, err: "either --from and --to, or the workspace, must be defined"},
+		"from without to":    {args: []string{"state-version", "diff", "--from", "sv-1"}, err: "--from and --to must be defined together"},
+		"from and workspace": {args: []string{"state-version", "diff", "--from", "sv-1", "--to", "sv-2", "--workspace-id", "ws-1"}, err: "can't be used along with the workspace"},
+		"zero versions ago":  {args: []string{"state-version", "diff", "--workspace-id", "ws-1", "--versions-ago", "0"}, err: "--versions-ago must be greater than zero"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.StateVersionCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/controller"
+)
+
+func TestTeamCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"empty":                 {args: []string{"team"}, err: "this command requires one argument"},
+		"wrong arg":             {args: []string{"team", "foo"}, err: "invalid argument"},
+		"list without org":      {args: []string{"team", "list"}, err: "--organization must be defined"},
+		"create without org":    {args: []string{"team", "create", "--name", "devs"}, err: "--organization must be defined"},
+		"create without name":   {args: []string{"team", "create", "--organization", "my-org"}, err: "--name must be defined"},
+		"create bad visibility": {args: []string{"team", "create", "--organization", "my-org", "--name", "devs", "--visibility", "public"}, err: "invalid value for --visibility"},
+		"read without id":       {args: []string{"team", "read"}, err: "--id must be defined"},
+		"delete without id":     {args: []string{"team", "delete"}, err: "--id must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.TeamCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestTeamMemberCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"list without team":     {args: []string{"team-member", "list"}, err: "--team-id must be defined"},
+		"add without users":     {args: []string{"team-member", "add", "--team-id", "team-123"}, err: "either --username or --user-id must be defined"},
+		"remove user id no org": {args: []string{"team-member", "remove", "--team-id", "team-123", "--user-id", "user-123"}, err: "--organization must be defined when using --user-id"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.TeamMemberCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestTeamAccessCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"list without workspace":    {args: []string{"team-access", "list"}, err: "either --workspace-id or --workspace must be defined"},
+		"add without team":          {args: []string{"team-access", "add", "--workspace-id", "ws-123", "--access", "read"}, err: "either --team-id or --team must be defined"},
+		"add team name no org":      {args: []string{"team-access", "add", "--workspace-id", "ws-123", "--team", "ops", "--access", "read"}, err: "--organization must be defined when using --team"},
+		"add without access":        {args: []string{"team-access", "add", "--workspace-id", "ws-123", "--team-id", "team-123"}, err: "--access must be defined"},
+		"add invalid access":        {args: []string{"team-access", "add", "--workspace-id", "ws-123", "--team-id", "team-123", "--access", "owner"}, err: "invalid value for access"},
+		"add custom without custom": {args: []string{"team-access", "add", "--workspace-id", "ws-123", "--team-id", "team-123", "--access", "read", "--runs", "apply"}, err: "runs can only be set with custom access"},
+		"read without id":           {args: []string{"team-access", "read"}, err: "--id must be defined, or the workspace and the team"},
+		"remove without team":       {args: []string{"team-access", "remove", "--workspace-id", "ws-123"}, err: "either --team-id or --team must be defined"},
+		"sync without file":         {args: []string{"team-access", "sync", "--organization", "my-org"}, err: "--file must be defined"},
+		"sync without org":          {args: []string{"team-access", "sync", "--file", "access.yaml"}, err: "--organization must be defined"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeCommand(t, controller.TeamAccessCmd(), tc.args)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/aid"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/dao"
+	"gitlab.aws.dev/devops-aws/tecli/cobra/model"
+)
+
+func TestCredentialsWithPlainAndEncryptedProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tecli")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	encrypted, err := aid.EncryptCredentialProfile(model.CredentialProfile{Name: "dao-encrypted", Enabled: true, TeamToken: "secret-team", Address: "https://tfe.example.com"}, "secret")
+	assert.Nil(t, err)
+
+	path := filepath.Join(dir, "credentials.yaml")
+	creds := model.Credentials{Profiles: []model.CredentialProfile{
+		{Name: "dao-plain", Enabled: true, TeamToken: "plain-team"},
+		encrypted,
+	}}
+	assert.Nil(t, aid.WriteInterfaceToFile(creds, path))
+
+	previous := viper.ConfigFileUsed()
+	defer viper.SetConfigFile(previous)
+	defer viper.Set("CREDENTIALS_PASSPHRASE", "")
+	viper.SetConfigFile(path)
+
+	// the plain profile is read without asking for the passphrase of the encrypted one
+	viper.Set("CREDENTIALS_PASSPHRASE", "")
+	cp, err := dao.GetCredentialProfile("dao-plain")
+	assert.Nil(t, err)
+	assert.Equal(t, "plain-team", cp.TeamToken)
+
+	// listing keeps the ciphertext
+	read, err := dao.GetCredentials()
+	assert.Nil(t, err)
+	assert.Equal(t, encrypted.Ciphertext, read.Profiles[1].Ciphertext)
+	assert.Empty(t, read.Profiles[1].TeamToken)
+
+	// saving doesn't encrypt the unchanged profile again, nor the plain one
+	assert.Nil(t, dao.SaveCredentials(read))
+	saved, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(saved), "teamToken: plain-team")
+	assert.Contains(t, string(saved), encrypted.Ciphertext)
+	assert.NotContains(t, string(saved), "secret-team")
+
+	// the address isn't encrypted, it's read without the passphrase
+	assert.Equal(t, "https://tfe.example.com", dao.GetAddress("dao-encrypted"))
+
+	viper.Set("CREDENTIALS_PASSPHRASE", "secret")
+	cp, err = dao.GetCredentialProfile("dao-encrypted")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret-team", cp.TeamToken)
+
+	viper.Set("CREDENTIALS_PASSPHRASE", "wrong")
+	_, err = dao.GetCredentialProfile("dao-encrypted")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+}